@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// Abort is a process stopped at its deadline before completing, having run for Ran of its burst.
+type Abort struct {
+	PID  int64 `json:"pid"`
+	Time int64 `json:"time"`
+	Ran  int64 `json:"ran"`
+}
+
+// withAborted returns the result with the aborted processes recorded, and left out of the stats and averages as
+// they never completed.
+func (r SchedulerResult) withAborted(aborted []Abort) SchedulerResult {
+	pids := make(map[int64]bool, len(aborted))
+	for _, a := range aborted {
+		pids[a.PID] = true
+	}
+	stats := make([]ProcessStats, 0, len(r.Stats))
+	for _, s := range r.Stats {
+		if !pids[s.ProcessID] {
+			stats = append(stats, s)
+		}
+	}
+	r.Aborted = aborted
+
+	return r.withStats(stats)
+}
+
+// outputAborted lists the processes aborted at their deadline and how much of their work they'd done.
+func outputAborted(w io.Writer, aborted []Abort, opts Options) {
+	if len(aborted) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "Aborted at deadline")
+	for _, a := range aborted {
+		_, _ = fmt.Fprintf(w, "Process %v at %v, after %v\n",
+			a.PID, formatTime(a.Time, opts.TimeScale), formatTime(a.Ran, opts.TimeScale))
+	}
+	_, _ = fmt.Fprintln(w)
+}
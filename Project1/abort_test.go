@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEDFSchedule_abortAtDeadline(t *testing.T) {
+	t.Parallel()
+	// Overloaded: 10 units of work all due by time 9.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Deadline: 5},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4, Deadline: 6},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2, Deadline: 9},
+	}
+	if m := realTimeMetrics(EDFSchedule("edf", processes, DefaultOptions()).Stats); m.Missed != 2 {
+		t.Fatalf("EDFSchedule() missed %v deadlines running to completion, want 2", m.Missed)
+	}
+
+	opts := DefaultOptions()
+	opts.AbortAtDeadline = true
+	got := EDFSchedule("edf", processes, opts)
+	wantGantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 4},
+		{PID: 2, Start: 4, Stop: 6},
+		{PID: 3, Start: 6, Stop: 8},
+	}
+	if !reflect.DeepEqual(got.Gantt, wantGantt) {
+		t.Errorf("EDFSchedule() gantt = %v, want %v", got.Gantt, wantGantt)
+	}
+	if want := []Abort{{PID: 2, Time: 6, Ran: 2}}; !reflect.DeepEqual(got.Aborted, want) {
+		t.Errorf("EDFSchedule() aborted = %v, want %v", got.Aborted, want)
+	}
+	if m := realTimeMetrics(got.Stats); m.Missed != 0 || m.Completed != 2 {
+		t.Errorf("realTimeMetrics() = %+v, want the 2 processes left to meet their deadlines", m)
+	}
+
+	var w bytes.Buffer
+	outputResult(&w, got, opts)
+	if want := "Process 2 at 6, after 2"; !strings.Contains(w.String(), want) {
+		t.Errorf("outputResult() missing %q: %v", want, w.String())
+	}
+}
+
+func TestEDFSchedule_abortAll(t *testing.T) {
+	t.Parallel()
+	opts := DefaultOptions()
+	opts.AbortAtDeadline = true
+	got := EDFSchedule("edf", []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Deadline: 2},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 5, Deadline: 3},
+	}, opts)
+	if got.Err != nil || len(got.Aborted) != 2 || len(got.Stats) != 0 || got.AveWait != 0 {
+		t.Errorf("EDFSchedule() = %+v, want both processes aborted", got)
+	}
+}
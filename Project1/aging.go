@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// defaultAgingInterval is how long a process waits in units of time for each level its priority ages by.
+const defaultAgingInterval = 5
+
+// PriorityChange is a process's effective priority from Time on, see AgingSchedule.
+type PriorityChange struct {
+	Time     int64
+	Priority int64
+}
+
+// aged is the priority improved by levels in the options' priority order.
+func (o Options) aged(priority, levels int64) int64 {
+	if o.PriorityOrder == PriorityDescending {
+		return priority + levels
+	}
+
+	return priority - levels
+}
+
+// AgingSchedule preemptively runs the ready process with the highest effective priority. A process's effective
+// priority starts at its priority and improves by one level for every opts.AgingInterval it waits in the ready
+// queue, so a low priority process can't starve behind higher ones. It keeps its effective priority while it runs,
+// and goes back to its priority when it's preempted or blocks for I/O.
+// A zero interval turns aging off, leaving plain preemptive priority scheduling. Ties go by opts.TieBreak.
+// The result's Aging is every process's effective priority over time.
+func AgingSchedule(title string, inputProcesses []Process, opts Options) SchedulerResult {
+	var (
+		interval = opts.AgingInterval
+		// waitingSince is when each ready process that isn't running started waiting, and levels how far its
+		// priority has aged since.
+		waitingSince = make(map[int64]int64)
+		levels       = make(map[int64]int64)
+		trajectory   = make(map[int64][]PriorityChange, len(inputProcesses))
+	)
+	record := func(pid, time, priority int64) {
+		changes := trajectory[pid]
+		if n := len(changes); n == 0 || changes[n-1].Priority != priority {
+			trajectory[pid] = append(changes, PriorityChange{Time: time, Priority: priority})
+		}
+	}
+	// current is the running process, which keeps its effective priority while it runs the burst currentIO ends.
+	var (
+		current   *runnable
+		currentIO int
+	)
+	wait := func(r *runnable, time int64) {
+		waitingSince[r.ProcessID], levels[r.ProcessID] = time, 0
+		record(r.ProcessID, time, r.Priority)
+	}
+	pick := func(ready []*runnable, time int64) int {
+		isReady := make(map[int64]bool, len(ready))
+		for _, r := range ready {
+			isReady[r.ProcessID] = true
+			if r == current && r.NextIO == currentIO {
+				continue
+			}
+			since, waiting := waitingSince[r.ProcessID]
+			if !waiting {
+				// It just arrived or returned from I/O.
+				wait(r, time)
+				since = time
+			}
+			for interval > 0 && levels[r.ProcessID] < (time-since)/interval {
+				levels[r.ProcessID]++
+				record(r.ProcessID, since+levels[r.ProcessID]*interval, opts.aged(r.Priority, levels[r.ProcessID]))
+			}
+		}
+		for pid := range waitingSince {
+			if !isReady[pid] {
+				delete(waitingSince, pid)
+			}
+		}
+
+		effective := func(r *runnable) int64 { return opts.aged(r.Priority, levels[r.ProcessID]) }
+		best := 0
+		for i := 1; i < len(ready); i++ {
+			a, b := effective(ready[i]), effective(ready[best])
+			if opts.higherPriority(a, b) || (a == b && opts.before(ready[i].Process, ready[best].Process, false)) {
+				best = i
+			}
+		}
+		if current != nil && current != ready[best] && isReady[current.ProcessID] && current.NextIO == currentIO {
+			// Preempted, it waits from its own priority again.
+			wait(current, time)
+		}
+		current, currentIO = ready[best], ready[best].NextIO
+		delete(waitingSince, current.ProcessID)
+
+		return best
+	}
+	// Choosing again every tick lets a waiting process's aged priority preempt the moment it's reached.
+	var quantum int64
+	if interval > 0 {
+		quantum = 1
+	}
+	gantt, ioGantt, err := preemptiveSchedule(inputProcesses, quantum, opts, pick)
+
+	result := calculateCompletionStats(title, inputProcesses, gantt)
+	result.IOGantt = ioGantt
+	result.Aging = trajectory
+	result.Err = err
+
+	return result
+}
+
+// explainAging explains AgingSchedule's choices, whose effective priorities are listed with -show-aging.
+func explainAging(candidate, []candidate, Options) string {
+	return "highest effective priority, aged while waiting"
+}
+
+// outputAging lists each process's effective priority over time, e.g. "4 (0), 3 (5), 2 (10)" for a process
+// of priority 4 waiting from time 0.
+func outputAging(w io.Writer, trajectory map[int64][]PriorityChange, opts Options) {
+	pids := make([]int64, 0, len(trajectory))
+	for pid := range trajectory {
+		pids = append(pids, pid)
+	}
+	sort.Slice(pids, func(a, b int) bool { return pids[a] < pids[b] })
+
+	_, _ = fmt.Fprintln(w, "Effective priorities")
+	table := newTable(w, opts)
+	table.SetHeader([]string{"ID", "Effective priority (time)"})
+	for _, pid := range pids {
+		changes := make([]string, len(trajectory[pid]))
+		for i, c := range trajectory[pid] {
+			changes[i] = fmt.Sprintf("%v (%v)", c.Priority, formatTime(c.Time, opts.TimeScale))
+		}
+		table.Append([]string{fmt.Sprint(pid), strings.Join(changes, ", ")})
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAgingSchedule(t *testing.T) {
+	t.Parallel()
+	// Process 2 waits behind the higher priority process 1, aging a level every 3 until it outranks it at 12.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 20, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2, Priority: 4},
+	}
+	opts := DefaultOptions()
+	opts.AgingInterval = 3
+	got := AgingSchedule("Preemptive priority (aging)", processes, opts)
+
+	wantGantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 12},
+		{PID: 2, Start: 12, Stop: 14},
+		{PID: 1, Start: 14, Stop: 22},
+	}
+	if !reflect.DeepEqual(got.Gantt, wantGantt) {
+		t.Errorf("AgingSchedule() gantt = %v, want %v", got.Gantt, wantGantt)
+	}
+	wantAging := []PriorityChange{
+		{Time: 0, Priority: 4}, {Time: 3, Priority: 3}, {Time: 6, Priority: 2}, {Time: 9, Priority: 1},
+		{Time: 12, Priority: 0},
+	}
+	if !reflect.DeepEqual(got.Aging[2], wantAging) {
+		t.Errorf("AgingSchedule() process 2 aged %v, want %v", got.Aging[2], wantAging)
+	}
+	// Process 1 only waits while process 2 runs, not long enough to age.
+	if want := []PriorityChange{{Time: 0, Priority: 1}}; !reflect.DeepEqual(got.Aging[1], want) {
+		t.Errorf("AgingSchedule() process 1 aged %v, want %v", got.Aging[1], want)
+	}
+
+	var w bytes.Buffer
+	opts.Plain = true
+	outputAging(&w, got.Aging, opts)
+	if want := "4 (0), 3 (3), 2 (6), 1 (9), 0 (12)"; !strings.Contains(w.String(), want) {
+		t.Errorf("outputAging() = %v, want %q", w.String(), want)
+	}
+}
+
+func TestAgingSchedule_noAging(t *testing.T) {
+	t.Parallel()
+	// Without aging process 2 starves until process 1 completes.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 20, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2, Priority: 4},
+	}
+	opts := DefaultOptions()
+	opts.AgingInterval = 0
+	got := AgingSchedule("Preemptive priority", processes, opts)
+	want := []TimeSlice{{PID: 1, Start: 0, Stop: 20}, {PID: 2, Start: 20, Stop: 22}}
+	if !reflect.DeepEqual(got.Gantt, want) {
+		t.Errorf("AgingSchedule() gantt = %v, want %v", got.Gantt, want)
+	}
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// ResourceRequest is a process asking for more of each resource once it has had At units of CPU time.
+type ResourceRequest struct {
+	At      int64
+	Amounts []int64
+}
+
+// ResourceScenario is processes sharing a fixed number of instances of each resource. They request the resources
+// a few at a time as they run, and release everything they hold when they complete.
+type ResourceScenario struct {
+	Processes []Process
+	// Total is how many instances of each resource there are.
+	Total []int64
+	// Max is each process's claim, the most of each resource it declares it may ever hold.
+	Max      map[int64][]int64
+	Requests map[int64][]ResourceRequest
+}
+
+// bankersScenario has two processes that each need both resources, which there's one of each of, taking them in
+// opposite orders. Round-robin hands each process its first resource before either asks for its second.
+var bankersScenario = ResourceScenario{
+	Processes: []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 4},
+	},
+	Total: []int64{1, 1},
+	Max:   map[int64][]int64{1: {1, 1}, 2: {1, 1}},
+	Requests: map[int64][]ResourceRequest{
+		1: {{At: 0, Amounts: []int64{1, 0}}, {At: 2, Amounts: []int64{0, 1}}},
+		2: {{At: 0, Amounts: []int64{0, 1}}, {At: 2, Amounts: []int64{1, 0}}},
+	},
+}
+
+// ResourceDecision is a request the Banker's algorithm deferred, though there was enough available to grant it,
+// as granting it would have left the system unsafe.
+type ResourceDecision struct {
+	Time      int64
+	PID       int64
+	Request   []int64
+	Available []int64
+}
+
+// covers reports whether have is at least want of every resource.
+func covers(have, want []int64) bool {
+	for r := range want {
+		if have[r] < want[r] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isSafe is the Banker's safety algorithm: whether the processes can all still finish in some order, each one
+// once what's available covers the rest of its claim, then releasing everything it holds. It returns such an
+// order of the pids.
+func isSafe(available []int64, allocation, max map[int64][]int64, pids []int64) ([]int64, bool) {
+	work := append([]int64(nil), available...)
+	finished := make(map[int64]bool, len(pids))
+	order := make([]int64, 0, len(pids))
+	for len(order) < len(pids) {
+		progressed := false
+		for _, pid := range pids {
+			if finished[pid] {
+				continue
+			}
+			need := make([]int64, len(work))
+			for r := range need {
+				need[r] = max[pid][r] - allocation[pid][r]
+			}
+			if !covers(work, need) {
+				continue
+			}
+			for r := range work {
+				work[r] += allocation[pid][r]
+			}
+			finished[pid], progressed = true, true
+			order = append(order, pid)
+		}
+		if !progressed {
+			return order, false
+		}
+	}
+
+	return order, true
+}
+
+// resourceState is what's allocated of the scenario's resources part way through a simulation.
+type resourceState struct {
+	available  []int64
+	allocation map[int64][]int64
+	// next indexes each process's next request.
+	next map[int64]int
+	// waiting are the requests of the processes that couldn't have them at the last pick.
+	waiting map[int64][]int64
+	// deadlock is the first deadlock detected, nil if there hasn't been one.
+	deadlock *Deadlock
+}
+
+// grant gives the process its request, if there's enough available and, with avoid, the state after is safe
+// going by the claims of the pids yet to complete. Otherwise it reports whether it was the Banker's algorithm
+// that refused.
+func (s *resourceState) grant(scenario ResourceScenario, pid int64, request []int64, avoid bool, pids []int64) (bool, bool) {
+	if !covers(s.available, request) {
+		return false, false
+	}
+	for r := range request {
+		s.available[r] -= request[r]
+		s.allocation[pid][r] += request[r]
+	}
+	if _, safe := isSafe(s.available, s.allocation, scenario.Max, pids); avoid && !safe {
+		for r := range request {
+			s.available[r] += request[r]
+			s.allocation[pid][r] -= request[r]
+		}
+		return false, true
+	}
+
+	return true, false
+}
+
+// release returns everything the process holds.
+func (s *resourceState) release(pid int64) {
+	for r := range s.available {
+		s.available[r] += s.allocation[pid][r]
+		s.allocation[pid][r] = 0
+	}
+}
+
+// resourceSchedule runs the scenario round-robin with the quantum, a tick at a time. A process making a request
+// waits, letting the next process in turn run, until its request can be granted. With avoid a request is only
+// granted if the Banker's algorithm finds the state after granting it safe, and the requests it deferred are
+// returned. Without it processes can deadlock, and the error is ErrNoProgress. Whenever processes are left waiting
+// the wait-for graph is checked for a cycle, and the first deadlock found is kept in the returned state.
+func resourceSchedule(
+	scenario ResourceScenario,
+	quantum int64,
+	avoid bool,
+	opts Options,
+) ([]TimeSlice, []ResourceDecision, *resourceState, error) {
+	state := &resourceState{
+		available:  append([]int64(nil), scenario.Total...),
+		allocation: make(map[int64][]int64, len(scenario.Processes)),
+		next:       make(map[int64]int, len(scenario.Processes)),
+		waiting:    make(map[int64][]int64),
+	}
+	for _, p := range scenario.Processes {
+		state.allocation[p.ProcessID] = make([]int64, len(scenario.Total))
+	}
+
+	var (
+		deferred = make([]ResourceDecision, 0)
+		// turns is the round-robin order of the processes that have arrived, the one whose turn it is first.
+		turns    = make([]int64, 0)
+		queued   = make(map[int64]bool)
+		used     int64
+		reported = make(map[int64]int)
+	)
+	gantt, _, err := preemptiveSchedule(scenario.Processes, 1, opts, func(ready []*runnable, time int64) int {
+		byPID := make(map[int64]int, len(ready))
+		for i, r := range ready {
+			byPID[r.ProcessID] = i
+			if !queued[r.ProcessID] {
+				queued[r.ProcessID] = true
+				turns = append(turns, r.ProcessID)
+			}
+		}
+		// A process that has left the ready queue has completed.
+		unfinished := make([]int64, 0, len(turns))
+		for _, pid := range turns {
+			if _, ok := byPID[pid]; ok {
+				unfinished = append(unfinished, pid)
+			} else {
+				state.release(pid)
+				used = 0
+			}
+		}
+		turns = unfinished
+		if used >= quantum && len(turns) > 1 {
+			turns, used = append(turns[1:], turns[0]), 0
+		}
+
+		defer func() {
+			if state.deadlock != nil || len(state.waiting) == 0 {
+				return
+			}
+			if cycle, ok := state.detectDeadlock(); ok {
+				state.deadlock = &Deadlock{Time: time, Cycle: cycle}
+			}
+		}()
+		for k, pid := range turns {
+			r := ready[byPID[pid]]
+			requests := scenario.Requests[pid]
+			if n := state.next[pid]; n < len(requests) && requests[n].At == r.BurstDuration-r.Remaining {
+				granted, unsafe := state.grant(scenario, pid, requests[n].Amounts, avoid, turns)
+				if !granted {
+					state.waiting[pid] = requests[n].Amounts
+					if unsafe && reported[pid] != n+1 {
+						reported[pid] = n + 1
+						deferred = append(deferred, ResourceDecision{
+							Time:      time,
+							PID:       pid,
+							Request:   requests[n].Amounts,
+							Available: append([]int64(nil), state.available...),
+						})
+					}
+					continue
+				}
+				state.next[pid]++
+				delete(state.waiting, pid)
+			}
+			if k > 0 {
+				// The processes whose turn it was are waiting, so this one takes its turn now.
+				turns, used = append(append([]int64{pid}, turns[:k]...), turns[k+1:]...), 0
+			}
+			used++
+			return byPID[pid]
+		}
+		return -1
+	})
+	if err == nil {
+		// The last process completed without another pick to notice.
+		for _, pid := range turns {
+			state.release(pid)
+		}
+	}
+
+	return gantt, deferred, state, err
+}
+
+// outputBankersDemo runs the resource scenario without and then with the Banker's algorithm, showing each gantt,
+// the deadlock detected without it, and the requests the Banker's algorithm deferred.
+func outputBankersDemo(w io.Writer, opts Options) {
+	scenario := bankersScenario
+	for _, avoid := range []bool{false, true} {
+		title := "Without deadlock avoidance"
+		if avoid {
+			title = "Banker's algorithm"
+		}
+		outputTitle(w, title)
+		gantt, deferred, state, err := resourceSchedule(scenario, opts.quantum(), avoid, opts)
+		outputGantt(w, "Gantt schedule", gantt, opts)
+		for _, d := range deferred {
+			_, _ = fmt.Fprintf(w, "Time %v: deferred process %v's request for %v, as granting it from %v available would be unsafe\n",
+				formatTime(d.Time, opts.TimeScale), d.PID, d.Request, d.Available)
+		}
+		if state.deadlock != nil {
+			outputDeadlock(w, *state.deadlock, opts)
+		} else if err != nil {
+			_, _ = fmt.Fprintf(w, "Deadlocked: %v\n", err)
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+}
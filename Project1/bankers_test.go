@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_isSafe(t *testing.T) {
+	t.Parallel()
+	// The textbook example of five processes and three resources.
+	max := map[int64][]int64{0: {7, 5, 3}, 1: {3, 2, 2}, 2: {9, 0, 2}, 3: {2, 2, 2}, 4: {4, 3, 3}}
+	pids := []int64{0, 1, 2, 3, 4}
+	tests := []struct {
+		name       string
+		available  []int64
+		allocation map[int64][]int64
+		wantOrder  []int64
+		wantSafe   bool
+	}{
+		{
+			name:       "safe",
+			available:  []int64{3, 3, 2},
+			allocation: map[int64][]int64{0: {0, 1, 0}, 1: {2, 0, 0}, 2: {3, 0, 2}, 3: {2, 1, 1}, 4: {0, 0, 2}},
+			wantOrder:  []int64{1, 3, 4, 0, 2},
+			wantSafe:   true,
+		},
+		{
+			// Process 1 has been granted (1, 0, 2), then process 0 (0, 2, 0), leaving too little for anyone's claim.
+			name:       "unsafe",
+			available:  []int64{2, 1, 0},
+			allocation: map[int64][]int64{0: {0, 3, 0}, 1: {3, 0, 2}, 2: {3, 0, 2}, 3: {2, 1, 1}, 4: {0, 0, 2}},
+			wantOrder:  []int64{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			order, safe := isSafe(tt.available, tt.allocation, max, pids)
+			if safe != tt.wantSafe || !reflect.DeepEqual(order, tt.wantOrder) {
+				t.Errorf("isSafe() = %v, %v, want %v, %v", order, safe, tt.wantOrder, tt.wantSafe)
+			}
+		})
+	}
+}
+
+func Test_resourceSchedule(t *testing.T) {
+	t.Parallel()
+	opts := DefaultOptions()
+
+	// Each process gets its first resource, then both wait forever for the other's.
+	gantt, deferred, _, err := resourceSchedule(bankersScenario, opts.quantum(), false, opts)
+	if !errors.Is(err, ErrNoProgress) || len(deferred) != 0 {
+		t.Errorf("resourceSchedule() without avoidance = %v, %v, want ErrNoProgress and nothing deferred", err, deferred)
+	}
+	if want := []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}}; !reflect.DeepEqual(gantt, want) {
+		t.Errorf("resourceSchedule() without avoidance gantt = %v, want %v", gantt, want)
+	}
+
+	// Granting process 2 the second resource at 2 would leave neither process able to finish, so it waits.
+	gantt, deferred, state, err := resourceSchedule(bankersScenario, opts.quantum(), true, opts)
+	if err != nil {
+		t.Fatalf("resourceSchedule() with the Banker's algorithm unexpected error: %v", err)
+	}
+	wantDeferred := []ResourceDecision{{Time: 2, PID: 2, Request: []int64{0, 1}, Available: []int64{0, 1}}}
+	if !reflect.DeepEqual(deferred, wantDeferred) {
+		t.Errorf("resourceSchedule() deferred %+v, want %+v", deferred, wantDeferred)
+	}
+	if want := []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 8}}; !reflect.DeepEqual(gantt, want) {
+		t.Errorf("resourceSchedule() with the Banker's algorithm gantt = %v, want %v", gantt, want)
+	}
+	if !reflect.DeepEqual(state.available, bankersScenario.Total) {
+		t.Errorf("resourceSchedule() left %v available, want everything released, %v", state.available, bankersScenario.Total)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// BatchStats is how long a batch of processes took as a whole, from its first member arriving to its last
+// completing, though its members were scheduled independently.
+type BatchStats struct {
+	Batch          int64
+	Processes      int
+	FirstArrival   int64
+	LastCompletion int64
+	Makespan       int64
+}
+
+// hasBatches reports if any of the processes is in a batch.
+func hasBatches(processes []Process) bool {
+	for i := range processes {
+		if processes[i].Batch != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// batchStats measures each batch the processes are in, in batch order, leaving out processes in no batch.
+func batchStats(stats []ProcessStats) []BatchStats {
+	byBatch := make(map[int64]*BatchStats)
+	for _, s := range stats {
+		if s.Batch == 0 {
+			continue
+		}
+		b, ok := byBatch[s.Batch]
+		if !ok {
+			b = &BatchStats{Batch: s.Batch, FirstArrival: s.ArrivalTime, LastCompletion: s.Completion}
+			byBatch[s.Batch] = b
+		}
+		b.Processes++
+		if s.ArrivalTime < b.FirstArrival {
+			b.FirstArrival = s.ArrivalTime
+		}
+		if s.Completion > b.LastCompletion {
+			b.LastCompletion = s.Completion
+		}
+	}
+
+	batches := make([]BatchStats, 0, len(byBatch))
+	for _, b := range byBatch {
+		b.Makespan = b.LastCompletion - b.FirstArrival
+		batches = append(batches, *b)
+	}
+	sort.Slice(batches, func(a, b int) bool { return batches[a].Batch < batches[b].Batch })
+
+	return batches
+}
+
+func outputBatchStats(w io.Writer, batches []BatchStats, opts Options) {
+	_, _ = fmt.Fprintln(w, "Batches")
+	table := newTable(w, opts)
+	table.SetHeader([]string{"Batch", "Processes", "First arrival", "Last completion", "Makespan"})
+	for _, b := range batches {
+		table.Append([]string{
+			fmt.Sprint(b.Batch),
+			fmt.Sprint(b.Processes),
+			formatTime(b.FirstArrival, opts.TimeScale),
+			formatTime(b.LastCompletion, opts.TimeScale),
+			formatTime(b.Makespan, opts.TimeScale),
+		})
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_batchStats(t *testing.T) {
+	t.Parallel()
+	processes, _, err := loadProcesses(strings.NewReader(
+		"1,4,0,0,0,0,,0,0,1\n" +
+			"2,3,1,0,0,0,,0,0,2\n" +
+			"3,2,2,0,0,0,,0,0,1\n" +
+			"4,5,3,0,0,0,,0,0,0\n" +
+			"5,1,6,0,0,0,,0,0,2\n"))
+	if err != nil {
+		t.Fatalf("loadProcesses() unexpected error: %v", err)
+	}
+	if !hasBatches(processes) {
+		t.Fatalf("hasBatches() = false, want true")
+	}
+
+	// First-come, first-serve completes the processes at 4, 7, 9, 14 and 15.
+	got := batchStats(FCFSSchedule("FCFS", processes, DefaultOptions()).Stats)
+	want := []BatchStats{
+		{Batch: 1, Processes: 2, FirstArrival: 0, LastCompletion: 9, Makespan: 9},
+		{Batch: 2, Processes: 2, FirstArrival: 1, LastCompletion: 15, Makespan: 14},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("batchStats() = %+v, want %+v", got, want)
+	}
+}
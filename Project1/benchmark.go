@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// Summary is the mean of a sample with the half-width of its 95% confidence interval.
+type Summary struct {
+	Mean float64
+	CI   float64
+}
+
+// summarize is the mean of the sample and its 95% confidence interval, by the normal approximation.
+func summarize(xs []float64) Summary {
+	if len(xs) == 0 {
+		return Summary{}
+	}
+	n := float64(len(xs))
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	mean := sum / n
+	if len(xs) == 1 {
+		return Summary{Mean: mean}
+	}
+
+	var squares float64
+	for _, x := range xs {
+		squares += (x - mean) * (x - mean)
+	}
+	stddev := math.Sqrt(squares / (n - 1))
+
+	return Summary{Mean: mean, CI: 1.96 * stddev / math.Sqrt(n)}
+}
+
+// Spread is the smallest, largest and mean of a sample.
+type Spread struct {
+	Min  float64
+	Max  float64
+	Mean float64
+}
+
+// spread is the range and mean of the sample.
+func spread(xs []float64) Spread {
+	if len(xs) == 0 {
+		return Spread{}
+	}
+	s := Spread{Min: xs[0], Max: xs[0]}
+	for _, x := range xs {
+		if x < s.Min {
+			s.Min = x
+		}
+		if x > s.Max {
+			s.Max = x
+		}
+		s.Mean += x
+	}
+	s.Mean /= float64(len(xs))
+
+	return s
+}
+
+// contextSwitches is how many times a core changed from running one process to another in the gantt,
+// whether directly or with the core idle between them.
+func contextSwitches(gantt []TimeSlice) int {
+	slices := make([]TimeSlice, len(gantt))
+	copy(slices, gantt)
+	sort.SliceStable(slices, func(a, b int) bool {
+		if slices[a].Core != slices[b].Core {
+			return slices[a].Core < slices[b].Core
+		}
+		return slices[a].Start < slices[b].Start
+	})
+
+	var switches int
+	for i := 1; i < len(slices); i++ {
+		if slices[i].Core == slices[i-1].Core && slices[i].PID != slices[i-1].PID {
+			switches++
+		}
+	}
+
+	return switches
+}
+
+// BenchmarkResult summarizes one scheduler's averages over every random workload.
+type BenchmarkResult struct {
+	Title      string
+	Wait       Summary
+	Turnaround Summary
+	Throughput Summary
+	// Switches is the spread of the context switches in each workload's schedule.
+	Switches Spread
+}
+
+// benchmark runs the selected schedulers on opts.Iterations random workloads generated from opts.Seed,
+// summarizing each scheduler's average wait, turnaround and throughput and the spread of its context switches.
+func benchmark(opts Options) []BenchmarkResult {
+	rng := newSeededRand(opts.Seed)
+	results := make([][]SchedulerResult, 0)
+	var schedulers []namedScheduler
+	for i := 0; i < opts.Iterations; i++ {
+		processes := generateWorkload(rng)
+		if schedulers == nil {
+			schedulers = selectSchedulers(opts, processes)
+			results = make([][]SchedulerResult, len(schedulers))
+		}
+		for j, s := range schedulers {
+			results[j] = append(results[j], s.schedule(s.title, processes, opts))
+		}
+	}
+
+	summaries := make([]BenchmarkResult, len(schedulers))
+	for j, s := range schedulers {
+		waits := make([]float64, len(results[j]))
+		turnarounds := make([]float64, len(results[j]))
+		throughputs := make([]float64, len(results[j]))
+		switches := make([]float64, len(results[j]))
+		for i, r := range results[j] {
+			waits[i], turnarounds[i], throughputs[i] = r.AveWait, r.AveTurnaround, r.Throughput
+			switches[i] = float64(contextSwitches(r.Gantt))
+		}
+		summaries[j] = BenchmarkResult{
+			Title:      s.title,
+			Wait:       summarize(waits),
+			Turnaround: summarize(turnarounds),
+			Throughput: summarize(throughputs),
+			Switches:   spread(switches),
+		}
+	}
+
+	return summaries
+}
+
+func outputBenchmark(w io.Writer, results []BenchmarkResult, opts Options) {
+	_, _ = fmt.Fprintf(w, "Averages over %v random workloads (seed %v), with 95%% confidence intervals\n", opts.Iterations, opts.Seed)
+	format := func(s Summary) string {
+		return formatFloat(s.Mean, opts.Precision) + " ± " + formatFloat(s.CI, opts.Precision)
+	}
+	table := newTable(w, opts)
+	table.SetHeader([]string{"Scheduler", "Wait", "Turnaround", "Throughput", "Switches min / mean / max"})
+	for _, r := range results {
+		switches := fmt.Sprintf("%v / %v / %v", r.Switches.Min, formatFloat(r.Switches.Mean, opts.Precision), r.Switches.Max)
+		table.Append([]string{r.Title, format(r.Wait), format(r.Turnaround), format(r.Throughput), switches})
+	}
+	table.Render()
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func Test_summarize(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		xs   []float64
+		want Summary
+	}{
+		{name: "empty", want: Summary{}},
+		{name: "single", xs: []float64{3}, want: Summary{Mean: 3}},
+		// Sample standard deviation 2, so 1.96 * 2 / sqrt(4).
+		{name: "sample", xs: []float64{2, 4, 4, 6}, want: Summary{Mean: 4, CI: 1.96 * math.Sqrt(8.0/3) / 2}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := summarize(tt.xs)
+			if math.Abs(got.Mean-tt.want.Mean) > 1e-9 || math.Abs(got.CI-tt.want.CI) > 1e-9 {
+				t.Errorf("summarize() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_benchmark(t *testing.T) {
+	t.Parallel()
+	opts := DefaultOptions()
+	opts.Iterations, opts.Seed, opts.Algorithms = 3, 42, []string{"fcfs", "rr"}
+
+	got := benchmark(opts)
+	if len(got) != 2 || got[0].Title != "First-come, first-serve" || got[1].Title != "Round-robin" {
+		t.Fatalf("benchmark() = %+v, want FCFS then RR", got)
+	}
+	if again := benchmark(opts); !reflect.DeepEqual(got, again) {
+		t.Errorf("benchmark() isn't deterministic for a seed: %+v then %+v", got, again)
+	}
+
+	// The summary is over the same workloads regenerated from the seed.
+	rng := newSeededRand(opts.Seed)
+	waits := make([]float64, opts.Iterations)
+	for i := range waits {
+		waits[i] = FCFSSchedule("", generateWorkload(rng), opts).AveWait
+	}
+	if want := summarize(waits); got[0].Wait != want {
+		t.Errorf("benchmark() FCFS wait = %+v, want %+v", got[0].Wait, want)
+	}
+}
+
+func Test_contextSwitches(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		gantt []TimeSlice
+		want  int
+	}{
+		{name: "empty", want: 0},
+		{name: "single process", gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 1, Start: 4, Stop: 6}}, want: 0},
+		{
+			// Back to process 1 after process 2 is a second switch, and the idle gap before process 3 doesn't
+			// hide the third.
+			name:  "one core",
+			gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 1, Start: 4, Stop: 5}, {PID: 3, Start: 8, Stop: 9}},
+			want:  3,
+		},
+		{
+			// Processes running side by side on different cores aren't switching.
+			name:  "two cores",
+			gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 3, Core: 0}, {PID: 2, Start: 0, Stop: 2, Core: 1}, {PID: 3, Start: 2, Stop: 4, Core: 1}},
+			want:  1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := contextSwitches(tt.gantt); got != tt.want {
+				t.Errorf("contextSwitches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_benchmark_switches(t *testing.T) {
+	t.Parallel()
+	opts := DefaultOptions()
+	opts.Iterations, opts.Seed, opts.Algorithms = 5, 7, []string{"fcfs", "rr"}
+
+	got := benchmark(opts)
+	for _, r := range got {
+		if r.Switches.Min > r.Switches.Mean || r.Switches.Mean > r.Switches.Max {
+			t.Errorf("benchmark() %v switches = %+v, want min <= mean <= max", r.Title, r.Switches)
+		}
+	}
+	// A workload of 8 processes switches at least 7 times, and round-robin's quantum only adds more.
+	if fcfs, rr := got[0].Switches, got[1].Switches; fcfs.Min < 7 || rr.Mean < fcfs.Mean {
+		t.Errorf("benchmark() switches FCFS %+v, RR %+v, want FCFS at least 7 and RR at least as many", fcfs, rr)
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// BoundedRRSchedule is round-robin with the same queueing as RRSchedule, new arrivals joining the front of the
+// ready queue and preempted processes the back, except a process that couldn't wait another quantum without
+// exceeding opts.MaxWait since it last ran (or arrived) is escalated to run next. If several must be escalated
+// the longest waiting goes first. Zero opts.MaxWait behaves like RRSchedule. Like RRSchedule it stops at the first
+// slice from opts.MaxTime on.
+func BoundedRRSchedule(title string, inputProcesses []Process, opts Options) SchedulerResult {
+	pending := make([]*runnable, len(inputProcesses))
+	for i := range inputProcesses {
+		pending[i] = &runnable{Process: inputProcesses[i], Remaining: inputProcesses[i].BurstDuration}
+	}
+	sort.SliceStable(pending, func(a, b int) bool {
+		return pending[a].ArrivalTime < pending[b].ArrivalTime
+	})
+
+	var (
+		time       int64
+		queue      = make([]*runnable, 0)
+		readySince = make(map[*runnable]int64, len(pending))
+		gantt      = make([]TimeSlice, 0)
+	)
+	for len(pending) > 0 || len(queue) > 0 {
+		if err := opts.cancelled(); err != nil {
+			result := calculateCompletionStats(title, cpuBound(inputProcesses), gantt)
+			result.Err = err
+			return result
+		}
+		if until := opts.until(); until >= 0 && time >= until {
+			break
+		}
+		for len(pending) > 0 && pending[0].ArrivalTime <= time {
+			readySince[pending[0]] = pending[0].ArrivalTime
+			queue = append([]*runnable{pending[0]}, queue...)
+			pending = pending[1:]
+		}
+		if len(queue) == 0 {
+			time = pending[0].ArrivalTime
+			continue
+		}
+
+		next := 0
+		if opts.MaxWait > 0 {
+			for i := range queue {
+				if time-readySince[queue[i]]+opts.quantum() > opts.MaxWait &&
+					readySince[queue[i]] < readySince[queue[next]] {
+					next = i
+				}
+			}
+		}
+		running := queue[next]
+		queue = append(queue[:next], queue[next+1:]...)
+
+		run := opts.quantum()
+		if running.Remaining < run {
+			run = running.Remaining
+		}
+		// Switching to a process that wasn't just running takes the dispatch latency, with the CPU idle.
+		if n := len(gantt); n == 0 || gantt[n-1].PID != running.ProcessID || gantt[n-1].Stop != time {
+			time += opts.DispatchLatency
+		}
+		if n := len(gantt); n > 0 && gantt[n-1].PID == running.ProcessID && gantt[n-1].Stop == time {
+			gantt[n-1].Stop += run
+		} else {
+			gantt = append(gantt, TimeSlice{PID: running.ProcessID, Start: time, Stop: time + run})
+		}
+		time += run
+		running.Remaining -= run
+		if running.Remaining > 0 {
+			readySince[running] = time
+			queue = append(queue, running)
+		}
+	}
+
+	result := calculateCompletionStats(title, cpuBound(inputProcesses), gantt)
+	result.WaitBound = opts.MaxWait
+
+	return result
+}
+
+// maxReadyWait is the longest any process waited in the ready queue between arriving, or its last slice or
+// I/O ending, and it next running.
+func maxReadyWait(result SchedulerResult) int64 {
+	readyAt := make(map[int64][]int64, len(result.Stats))
+	for _, s := range result.Stats {
+		readyAt[s.ProcessID] = append(readyAt[s.ProcessID], s.ArrivalTime)
+	}
+	for _, s := range result.IOGantt {
+		readyAt[s.PID] = append(readyAt[s.PID], s.Stop)
+	}
+
+	var longest int64
+	for _, s := range result.Gantt {
+		readyAt[s.PID] = append(readyAt[s.PID], s.Stop)
+	}
+	for _, s := range result.Gantt {
+		// The process became ready at the latest arrival, slice or I/O end before this slice started.
+		var since int64 = -1
+		for _, t := range readyAt[s.PID] {
+			if t <= s.Start && t > since {
+				since = t
+			}
+		}
+		if since >= 0 && s.Start-since > longest {
+			longest = s.Start - since
+		}
+	}
+
+	return longest
+}
+
+func outputWaitBound(w io.Writer, result SchedulerResult, scale int64) {
+	_, _ = fmt.Fprintf(w, "Max wait between turns: %v (bound %v)\n\n",
+		formatTime(maxReadyWait(result), scale), formatTime(result.WaitBound, scale))
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBoundedRRSchedule(t *testing.T) {
+	t.Parallel()
+	// Each new arrival jumps the queue, so under plain round-robin process 1 waits from 2 until 8.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6},
+		{ProcessID: 2, ArrivalTime: 2, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 4, BurstDuration: 2},
+		{ProcessID: 4, ArrivalTime: 6, BurstDuration: 4},
+	}
+	const bound = 4
+
+	if got := maxReadyWait(RRSchedule("RR", processes, DefaultOptions())); got <= bound {
+		t.Fatalf("RRSchedule() max wait = %v, want it over the bound %v", got, bound)
+	}
+
+	opts := DefaultOptions()
+	opts.MaxWait = bound
+	got := BoundedRRSchedule("RR", processes, opts)
+
+	wantGantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 2, Start: 2, Stop: 4},
+		{PID: 3, Start: 4, Stop: 6},
+		{PID: 1, Start: 6, Stop: 8},
+		{PID: 4, Start: 8, Stop: 10},
+		{PID: 1, Start: 10, Stop: 12},
+		{PID: 4, Start: 12, Stop: 14},
+	}
+	if !reflect.DeepEqual(got.Gantt, wantGantt) {
+		t.Errorf("BoundedRRSchedule() gantt = %v, want %v", got.Gantt, wantGantt)
+	}
+	if wait := maxReadyWait(got); wait > bound {
+		t.Errorf("BoundedRRSchedule() max wait = %v, want at most %v", wait, bound)
+	}
+
+	var w bytes.Buffer
+	outputResult(&w, got, opts)
+	if !strings.Contains(w.String(), "Max wait between turns: 4 (bound 4)") {
+		t.Errorf("outputResult() missing max wait: %v", w.String())
+	}
+}
+
+func TestBoundedRRSchedule_unbounded(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 4},
+	}
+	want := RRSchedule("RR", processes, DefaultOptions()).Gantt
+	if got := BoundedRRSchedule("RR", processes, DefaultOptions()).Gantt; !reflect.DeepEqual(got, want) {
+		t.Errorf("BoundedRRSchedule() without a bound = %v, want RRSchedule's %v", got, want)
+	}
+}
+
+func TestBoundedRRSchedule_ignoresIO(t *testing.T) {
+	t.Parallel()
+	withIO := []Process{
+		{ProcessID: 1, BurstDuration: 4, IO: []IORequest{{At: 1, Duration: 5}}},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+	}
+	got := BoundedRRSchedule("RR", withIO, DefaultOptions())
+	want := BoundedRRSchedule("RR", cpuBound(withIO), DefaultOptions())
+	for i := range got.Stats {
+		if got.Stats[i].Wait < 0 || got.Stats[i].Wait != want.Stats[i].Wait {
+			t.Errorf("BoundedRRSchedule() process %v wait = %v, want the CPU-only %v",
+				got.Stats[i].ProcessID, got.Stats[i].Wait, want.Stats[i].Wait)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// WorkloadBounds are lower bounds on how well any scheduler can do with a workload, derived without scheduling it.
+type WorkloadBounds struct {
+	// TotalWork is the sum of the bursts.
+	TotalWork int64
+	// MinMakespan is the earliest the last process could possibly complete.
+	MinMakespan int64
+	// ForcedIdle is the core time up to MinMakespan that no schedule can fill, for want of processes that have arrived.
+	ForcedIdle int64
+	// OptimalWait is the average wait of shortest-job-first, the least any schedule can manage when the
+	// processes all arrive together.
+	OptimalWait float64
+}
+
+// workloadBounds derives the workload's bounds on the cores. No schedule can finish the work arriving from
+// any arrival onwards before that work has been shared across the cores, nor a process before its own burst
+// has run, which bounds the makespan. With a single core the bound is met by any scheduler that never idles
+// while processes are ready. The optimal wait deals the processes out to the cores shortest first.
+func workloadBounds(processes []Process, cores int) WorkloadBounds {
+	if cores < 1 {
+		cores = 1
+	}
+	sorted := append([]Process(nil), processes...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ArrivalTime < sorted[j].ArrivalTime })
+
+	var b WorkloadBounds
+	for _, p := range sorted {
+		b.TotalWork += p.BurstDuration
+		if stop := p.ArrivalTime + p.BurstDuration; stop > b.MinMakespan {
+			b.MinMakespan = stop
+		}
+	}
+	// later is the work arriving at or after the i'th arrival.
+	later := b.TotalWork
+	for i, p := range sorted {
+		if i == 0 || p.ArrivalTime != sorted[i-1].ArrivalTime {
+			if stop := p.ArrivalTime + (later+int64(cores)-1)/int64(cores); stop > b.MinMakespan {
+				b.MinMakespan = stop
+			}
+		}
+		later -= p.BurstDuration
+	}
+	b.ForcedIdle = b.MinMakespan*int64(cores) - b.TotalWork
+
+	bursts := make([]int64, len(processes))
+	for i, p := range processes {
+		bursts[i] = p.BurstDuration
+	}
+	sort.Slice(bursts, func(i, j int) bool { return bursts[i] < bursts[j] })
+	free := make([]int64, cores)
+	var wait int64
+	for i, burst := range bursts {
+		wait += free[i%cores]
+		free[i%cores] += burst
+	}
+	if len(bursts) > 0 {
+		b.OptimalWait = float64(wait) / float64(len(bursts))
+	}
+
+	return b
+}
+
+// outputWorkloadBounds writes the workload's bounds, for -workload-stats.
+func outputWorkloadBounds(w io.Writer, b WorkloadBounds, opts Options) {
+	wait := b.OptimalWait
+	if opts.TimeScale > 1 {
+		wait /= float64(opts.TimeScale)
+	}
+	outputTitle(w, "Workload bounds")
+	_, _ = fmt.Fprintf(w, "Total work:    %v\n", formatTime(b.TotalWork, opts.TimeScale))
+	_, _ = fmt.Fprintf(w, "Min makespan:  %v\n", formatTime(b.MinMakespan, opts.TimeScale))
+	_, _ = fmt.Fprintf(w, "Forced idle:   %v\n", formatTime(b.ForcedIdle, opts.TimeScale))
+	_, _ = fmt.Fprintf(w, "Optimal wait:  %v (shortest-job-first, all arriving together)\n\n", formatFloat(wait, opts.Precision))
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_workloadBounds(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		cores     int
+		want      WorkloadBounds
+	}{
+		{
+			name: "all arriving at zero",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 6},
+				{ProcessID: 2, BurstDuration: 8},
+				{ProcessID: 3, BurstDuration: 7},
+				{ProcessID: 4, BurstDuration: 3},
+			},
+			cores: 1,
+			// Waits of 0, 3, 9 and 16 running 4, 1, 3 then 2.
+			want: WorkloadBounds{TotalWork: 24, MinMakespan: 24, OptimalWait: 7},
+		},
+		{
+			name: "forced idle",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 2, BurstDuration: 3},
+				{ProcessID: 2, ArrivalTime: 10, BurstDuration: 2},
+			},
+			cores: 1,
+			// The optimal wait ignores the arrivals.
+			want: WorkloadBounds{TotalWork: 5, MinMakespan: 12, ForcedIdle: 7, OptimalWait: 1},
+		},
+		{
+			name: "two cores",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 2},
+				{ProcessID: 2, BurstDuration: 2},
+				{ProcessID: 3, BurstDuration: 3},
+			},
+			cores: 2,
+			// Process 3 waits for one of the others.
+			want: WorkloadBounds{TotalWork: 7, MinMakespan: 4, ForcedIdle: 1, OptimalWait: 2.0 / 3},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := workloadBounds(tt.processes, tt.cores); got != tt.want {
+				t.Errorf("workloadBounds() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_workloadBounds_optimalWait(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5},
+		{ProcessID: 2, BurstDuration: 1},
+		{ProcessID: 3, BurstDuration: 9},
+		{ProcessID: 4, BurstDuration: 2},
+	}
+	bounds := workloadBounds(processes, 1)
+	if got := SJFSchedule("sjf", processes, DefaultOptions()); got.AveWait != bounds.OptimalWait {
+		t.Errorf("SJFSchedule() average wait = %v, want the bound %v", got.AveWait, bounds.OptimalWait)
+	}
+	if got := FCFSSchedule("fcfs", processes, DefaultOptions()); got.AveWait < bounds.OptimalWait {
+		t.Errorf("FCFSSchedule() average wait = %v, want at least the bound %v", got.AveWait, bounds.OptimalWait)
+	}
+	if got := FCFSSchedule("fcfs", processes, DefaultOptions()); ganttMakespan(got.Gantt) != bounds.MinMakespan {
+		t.Errorf("FCFSSchedule() makespan = %v, want the bound %v", ganttMakespan(got.Gantt), bounds.MinMakespan)
+	}
+}
+
+func Test_outputWorkloadBounds(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	outputWorkloadBounds(&w, WorkloadBounds{TotalWork: 24, MinMakespan: 26, ForcedIdle: 2, OptimalWait: 7}, DefaultOptions())
+	for _, line := range []string{"Total work:    24", "Min makespan:  26", "Forced idle:   2", "Optimal wait:  7.00"} {
+		if !strings.Contains(w.String(), line) {
+			t.Errorf("outputWorkloadBounds() missing %q, got:\n%v", line, w.String())
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// csvColumns are the CSV columns in the order they're read without -columns.
+var csvColumns = []string{"id", "burst", "arrival", "priority", "affinity", "deadline", "io", "period", "weight", "batch", "instances", "nice", "value"}
+
+// requiredColumns are the CSV columns every row needs.
+var requiredColumns = csvColumns[:3]
+
+// columnIndex is the position of the named column in csvColumns, -1 if there's no such column.
+func columnIndex(name string) int {
+	for i, c := range csvColumns {
+		if c == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// parseColumns parses a -columns mapping, the comma separated names of a CSV file's columns in its order,
+// such as "arrival,burst,id". Each column may appear once and the required ones must all appear.
+func parseColumns(s string) ([]string, error) {
+	columns := strings.Split(s, ",")
+	seen := make(map[string]bool, len(columns))
+	for i := range columns {
+		columns[i] = strings.ToLower(strings.TrimSpace(columns[i]))
+		if columnIndex(columns[i]) < 0 {
+			return nil, fmt.Errorf("%w: unknown column %q, want one of %v",
+				ErrInvalidArgs, columns[i], strings.Join(csvColumns, ", "))
+		}
+		if seen[columns[i]] {
+			return nil, fmt.Errorf("%w: column %q appears more than once", ErrInvalidArgs, columns[i])
+		}
+		seen[columns[i]] = true
+	}
+	for _, c := range requiredColumns {
+		if !seen[c] {
+			return nil, fmt.Errorf("%w: columns are missing the required %q", ErrInvalidArgs, c)
+		}
+	}
+
+	return columns, nil
+}
+
+// reorderColumns rearranges rows whose fields are in the columns' order into the order of csvColumns,
+// the columns left out being zero and any fields past the last column being ignored. A row too short to have
+// one of the columns leaves it empty, which fails to parse if it's a required one.
+// Nil columns leave the rows as they are.
+func reorderColumns(rows [][]string, columns []string) [][]string {
+	if columns == nil {
+		return rows
+	}
+	width := 0
+	for _, c := range columns {
+		if i := columnIndex(c); i+1 > width {
+			width = i + 1
+		}
+	}
+
+	reordered := make([][]string, len(rows))
+	for r, row := range rows {
+		fields := make([]string, width)
+		for i := range fields {
+			if csvColumns[i] != "io" && csvColumns[i] != "nice" {
+				fields[i] = "0"
+			}
+		}
+		for from, c := range columns {
+			to := columnIndex(c)
+			if from < len(row) {
+				fields[to] = row[from]
+			} else {
+				fields[to] = ""
+			}
+		}
+		reordered[r] = fields
+	}
+
+	return reordered
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseColumns(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		s       string
+		want    []string
+		wantErr bool
+	}{
+		{name: "reordered", s: "arrival, burst,ID", want: []string{"arrival", "burst", "id"}},
+		{name: "optional", s: "id,priority,burst,arrival", want: []string{"id", "priority", "burst", "arrival"}},
+		{name: "missing required", s: "id,burst,priority", wantErr: true},
+		{name: "unknown", s: "id,burst,arrival,tickets", wantErr: true},
+		{name: "duplicate", s: "id,burst,arrival,id", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseColumns(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseColumns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseColumns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_readProcesses_columns(t *testing.T) {
+	t.Parallel()
+	csv := "0,5,1,2\n3,2,2,1\n"
+	got, scale, _, err := readProcesses(strings.NewReader(csv), []string{"arrival", "burst", "id", "priority"}, nil)
+	if err != nil {
+		t.Fatalf("readProcesses() error = %v", err)
+	}
+	want := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2, Line: 1},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 2, Priority: 1, Line: 2},
+	}
+	if !reflect.DeepEqual(got, want) || scale != 1 {
+		t.Errorf("readProcesses() = %+v at scale %v, want %+v at scale 1", got, scale, want)
+	}
+}
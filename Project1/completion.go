@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// completionOrder is the stats in the order the processes finished, processes finishing together in the
+// order of the stats.
+func completionOrder(stats []ProcessStats) []ProcessStats {
+	order := make([]ProcessStats, len(stats))
+	copy(order, stats)
+	sort.SliceStable(order, func(a, b int) bool { return order[a].Completion < order[b].Completion })
+
+	return order
+}
+
+// outputCompletionOrder lists the processes by when they finished, e.g. "Completion order: 2 (3), 1 (7)".
+func outputCompletionOrder(w io.Writer, order []ProcessStats, opts Options) {
+	finished := make([]string, len(order))
+	for i, s := range order {
+		finished[i] = fmt.Sprintf("%v (%v)", s.ProcessID, formatTime(s.Completion, opts.TimeScale))
+	}
+	_, _ = fmt.Fprintf(w, "Completion order: %v\n\n", strings.Join(finished, ", "))
+}
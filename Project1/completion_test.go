@@ -0,0 +1,22 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_outputCompletionOrder(t *testing.T) {
+	t.Parallel()
+	// The long job arrives first but the shorter ones preempt it, finishing first.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 10},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 4},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1},
+	}
+	result := SJFSchedule("SJF", processes, DefaultOptions())
+	var w bytes.Buffer
+	outputCompletionOrder(&w, completionOrder(result.Stats), DefaultOptions())
+	if want := "Completion order: 3 (3), 2 (6), 1 (15)\n\n"; w.String() != want {
+		t.Errorf("outputCompletionOrder() = %q, want %q", w.String(), want)
+	}
+}
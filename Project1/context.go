@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+)
+
+// WithContext returns the options with a context that abandons the schedulers run with them once it's done,
+// so a server or TUI embedding the schedulers can stop a long simulation. An abandoned scheduler returns the
+// schedule as far as it got, with the context's error as SchedulerResult.Err.
+func (o Options) WithContext(ctx context.Context) Options {
+	o.ctx = ctx
+
+	return o
+}
+
+// ScheduleContext runs the scheduler with options abandoning it once ctx is done, see Options.WithContext.
+func ScheduleContext(ctx context.Context, schedule Scheduler, title string, processes []Process, opts Options) SchedulerResult {
+	return schedule(title, processes, opts.WithContext(ctx))
+}
+
+// cancelled is the error of the options' context once it's done, nil until then or if there isn't one.
+func (o Options) cancelled() error {
+	return contextErr(o.ctx)
+}
+
+// contextErr is the context's error, nil for a nil context.
+func contextErr(ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+
+	return ctx.Err()
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestScheduleContext(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 1000000},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 1000000},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	picks := 0
+	got := ScheduleContext(ctx, func(title string, processes []Process, opts Options) SchedulerResult {
+		gantt, _, err := preemptiveSchedule(processes, 1, opts, func(ready []*runnable, _ int64) int {
+			// Cancel part way through, as a server whose client gave up would.
+			if picks++; picks == 10 {
+				cancel()
+			}
+			return picks % len(ready)
+		})
+		result := calculateCompletionStats(title, processes, gantt)
+		result.Err = err
+		return result
+	}, "cancelled", processes, DefaultOptions())
+
+	if !errors.Is(got.Err, context.Canceled) {
+		t.Errorf("ScheduleContext() error = %v, want %v", got.Err, context.Canceled)
+	}
+	if n := len(got.Gantt); n == 0 || got.Gantt[n-1].Stop != 10 {
+		t.Errorf("ScheduleContext() gantt = %v, want the 10 ticks run before cancelling", got.Gantt)
+	}
+}
+
+func TestScheduleContext_cancelled(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 5},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name     string
+		schedule Scheduler
+	}{
+		{name: "RRSchedule", schedule: RRSchedule},
+		{name: "BoundedRRSchedule", schedule: BoundedRRSchedule},
+		{name: "MultiCoreRRSchedule", schedule: MultiCoreRRSchedule},
+		{name: "EDFSchedule", schedule: EDFSchedule},
+		{name: "SJFPrioritySchedule", schedule: SJFPrioritySchedule},
+		{name: "DVFSSchedule", schedule: DVFSSchedule},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := ScheduleContext(ctx, tt.schedule, tt.name, processes, DefaultOptions())
+			if !errors.Is(got.Err, context.Canceled) {
+				t.Errorf("%v() error = %v, want %v", tt.name, got.Err, context.Canceled)
+			}
+			if len(got.Gantt) != 0 {
+				t.Errorf("%v() gantt = %v, want nothing run", tt.name, got.Gantt)
+			}
+		})
+	}
+}
+
+// countdownContext is cancelled once its error has been checked after times.
+type countdownContext struct {
+	context.Context
+	after int
+}
+
+func (c *countdownContext) Err() error {
+	if c.after--; c.after < 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestSJFPrioritySchedule_cancelledPartWay(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 2, BurstDuration: 1},
+		{ProcessID: 3, ArrivalTime: 5, BurstDuration: 3},
+	}
+	ctx := &countdownContext{Context: context.Background(), after: 2}
+	got := ScheduleContext(ctx, SJFPrioritySchedule, "cancelled", processes, DefaultOptions())
+
+	if !errors.Is(got.Err, context.Canceled) {
+		t.Errorf("SJFPrioritySchedule() error = %v, want %v", got.Err, context.Canceled)
+	}
+	// Process 1 ran until process 2 preempted it, then the simulation was abandoned.
+	if want := []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 3}}; !reflect.DeepEqual(got.Gantt, want) {
+		t.Errorf("SJFPrioritySchedule() gantt = %v, want the partial schedule %v", got.Gantt, want)
+	}
+}
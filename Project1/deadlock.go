@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// WaitFor is an edge of the wait-for graph: a process waiting for more of a resource than is available,
+// which another process holds some of.
+type WaitFor struct {
+	PID      int64
+	Resource int
+	Holder   int64
+}
+
+// Deadlock is a cycle of processes each waiting for a resource the next one holds, found at Time.
+type Deadlock struct {
+	Time  int64
+	Cycle []WaitFor
+}
+
+// pids are the deadlocked processes, in the cycle's order.
+func (d Deadlock) pids() []int64 {
+	pids := make([]int64, len(d.Cycle))
+	for i, e := range d.Cycle {
+		pids[i] = e.PID
+	}
+
+	return pids
+}
+
+// waitsFor are the edges of the wait-for graph out of the process, built from what it's waiting for and what
+// the other processes hold, in order of resource then holder.
+func (s *resourceState) waitsFor(pid int64) []WaitFor {
+	request, ok := s.waiting[pid]
+	if !ok {
+		return nil
+	}
+	holders := make([]int64, 0, len(s.allocation))
+	for holder := range s.allocation {
+		holders = append(holders, holder)
+	}
+	sort.Slice(holders, func(a, b int) bool { return holders[a] < holders[b] })
+
+	edges := make([]WaitFor, 0)
+	for r := range request {
+		if request[r] <= s.available[r] {
+			continue
+		}
+		for _, holder := range holders {
+			if holder != pid && s.allocation[holder][r] > 0 {
+				edges = append(edges, WaitFor{PID: pid, Resource: r, Holder: holder})
+			}
+		}
+	}
+
+	return edges
+}
+
+// detectDeadlock searches the wait-for graph for a cycle, depth first from each waiting process in PID order,
+// returning the first one found.
+func (s *resourceState) detectDeadlock() ([]WaitFor, bool) {
+	pids := make([]int64, 0, len(s.waiting))
+	for pid := range s.waiting {
+		pids = append(pids, pid)
+	}
+	sort.Slice(pids, func(a, b int) bool { return pids[a] < pids[b] })
+
+	const (
+		unvisited = iota
+		onPath
+		explored
+	)
+	state := make(map[int64]int, len(pids))
+	path := make([]WaitFor, 0)
+	var visit func(pid int64) []WaitFor
+	visit = func(pid int64) []WaitFor {
+		state[pid] = onPath
+		for _, e := range s.waitsFor(pid) {
+			switch state[e.Holder] {
+			case onPath:
+				// The cycle is the path from the holder's edge on.
+				path = append(path, e)
+				for i := range path {
+					if path[i].PID == e.Holder {
+						return append([]WaitFor(nil), path[i:]...)
+					}
+				}
+			case unvisited:
+				path = append(path, e)
+				if cycle := visit(e.Holder); cycle != nil {
+					return cycle
+				}
+				path = path[:len(path)-1]
+			}
+		}
+		state[pid] = explored
+		return nil
+	}
+	for _, pid := range pids {
+		if state[pid] == unvisited {
+			if cycle := visit(pid); cycle != nil {
+				return cycle, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func outputDeadlock(w io.Writer, d Deadlock, opts Options) {
+	edges := make([]string, len(d.Cycle))
+	for i, e := range d.Cycle {
+		edges[i] = fmt.Sprintf("process %v waits for resource %v held by process %v", e.PID, e.Resource, e.Holder)
+	}
+	_, _ = fmt.Fprintf(w, "Deadlock detected at time %v among processes %v: %v\n",
+		formatTime(d.Time, opts.TimeScale), d.pids(), strings.Join(edges, ", "))
+}
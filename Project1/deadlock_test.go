@@ -0,0 +1,53 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_detectDeadlock(t *testing.T) {
+	t.Parallel()
+	// Processes 2 and 3 each hold the resource the other is waiting for. Process 1 is waiting behind process 2
+	// but isn't part of the circular wait, and process 4 holds a resource nobody is waiting for.
+	state := &resourceState{
+		available: []int64{0, 0, 0},
+		allocation: map[int64][]int64{
+			1: {0, 0, 0},
+			2: {1, 0, 0},
+			3: {0, 1, 0},
+			4: {0, 0, 1},
+		},
+		waiting: map[int64][]int64{
+			1: {1, 0, 0},
+			2: {0, 1, 0},
+			3: {1, 0, 0},
+		},
+	}
+	cycle, ok := state.detectDeadlock()
+	want := []WaitFor{{PID: 2, Resource: 1, Holder: 3}, {PID: 3, Resource: 0, Holder: 2}}
+	if !ok || !reflect.DeepEqual(cycle, want) {
+		t.Errorf("detectDeadlock() = %v, %v, want %v", cycle, ok, want)
+	}
+
+	// Once process 3 is granted what it's waiting for, process 2 only waits on a process that can finish.
+	delete(state.waiting, 3)
+	if cycle, ok := state.detectDeadlock(); ok {
+		t.Errorf("detectDeadlock() without a circular wait = %v, want none", cycle)
+	}
+}
+
+func Test_resourceSchedule_deadlock(t *testing.T) {
+	t.Parallel()
+	opts := DefaultOptions()
+	_, _, state, _ := resourceSchedule(bankersScenario, opts.quantum(), false, opts)
+	if state.deadlock == nil {
+		t.Fatal("resourceSchedule() without avoidance detected no deadlock")
+	}
+	if got := state.deadlock.pids(); state.deadlock.Time != 4 || !reflect.DeepEqual(got, []int64{1, 2}) {
+		t.Errorf("resourceSchedule() detected a deadlock of %v at %v, want processes [1 2] at 4", got, state.deadlock.Time)
+	}
+
+	if _, _, state, _ := resourceSchedule(bankersScenario, opts.quantum(), true, opts); state.deadlock != nil {
+		t.Errorf("resourceSchedule() with the Banker's algorithm detected a deadlock %+v", *state.deadlock)
+	}
+}
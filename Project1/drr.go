@@ -0,0 +1,60 @@
+package main
+
+// DeficitRRSchedule schedules processes round-robin, but each turn a process is credited a quantum times its weight,
+// read from Weight where a bigger number is a bigger weight, and runs until its credit is spent. Credit a process
+// doesn't spend because it blocked for I/O carries forward to its next turn, so processes with short CPU bursts
+// aren't shortchanged, and over many rounds each process gets CPU in proportion to its weight.
+func DeficitRRSchedule(title string, inputProcesses []Process, opts Options) SchedulerResult {
+	deficit := make(map[int64]int64, len(inputProcesses))
+	credit := func(p Process) int64 {
+		return opts.quantum() * p.weight()
+	}
+
+	// queue is the round's order, its front is the process taking its turn.
+	var (
+		queue   = make([]*runnable, 0)
+		current *runnable
+	)
+	gantt, ioGantt, err := preemptiveSchedule(inputProcesses, 1, opts, func(ready []*runnable, _ int64) int {
+		isReady := make(map[*runnable]bool, len(ready))
+		for _, r := range ready {
+			isReady[r] = true
+		}
+		kept := queue[:0]
+		queued := make(map[*runnable]bool, len(queue))
+		for _, r := range queue {
+			if isReady[r] {
+				kept = append(kept, r)
+				queued[r] = true
+			}
+		}
+		queue = kept
+		for _, r := range ready {
+			if !queued[r] {
+				queue = append(queue, r)
+			}
+		}
+
+		for queue[0] != current || deficit[current.ProcessID] <= 0 {
+			if queue[0] == current {
+				queue = append(queue[1:], current)
+			}
+			current = queue[0]
+			deficit[current.ProcessID] += credit(current.Process)
+		}
+		deficit[current.ProcessID]--
+
+		for i := range ready {
+			if ready[i] == current {
+				return i
+			}
+		}
+
+		return 0
+	})
+	result := calculateCompletionStats(title, inputProcesses, gantt)
+	result.IOGantt = ioGantt
+	result.Err = err
+
+	return result
+}
@@ -0,0 +1,56 @@
+package main
+
+import "testing"
+
+func TestDeficitRRSchedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		window    int64
+		want      map[int64]int64
+	}{
+		{
+			name: "differently sized jobs share equally",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 5, Weight: 1},
+				{ProcessID: 2, BurstDuration: 30, Weight: 1},
+				{ProcessID: 3, BurstDuration: 30, Weight: 1},
+			},
+			window: 12,
+			want:   map[int64]int64{1: 4, 2: 4, 3: 4},
+		},
+		{
+			name: "shares follow weights",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 40, Weight: 1},
+				{ProcessID: 2, BurstDuration: 40, Weight: 1},
+				{ProcessID: 3, BurstDuration: 40, Weight: 2},
+			},
+			window: 16,
+			want:   map[int64]int64{1: 4, 2: 4, 3: 8},
+		},
+		{
+			name: "credit left by blocking carries forward",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 8, Weight: 1, IO: []IORequest{{At: 1, Duration: 1}}},
+				{ProcessID: 2, BurstDuration: 8, Weight: 1},
+			},
+			window: 6,
+			want:   map[int64]int64{1: 4, 2: 2},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := DeficitRRSchedule("Deficit round-robin", tt.processes, DefaultOptions())
+			got := cpuTime(result.Gantt, tt.window)
+			for pid, want := range tt.want {
+				if got[pid] != want {
+					t.Errorf("DeficitRRSchedule() process %v ran %v of the first %v, want %v", pid, got[pid], tt.window, want)
+				}
+			}
+		})
+	}
+}
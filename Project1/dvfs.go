@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// DVFS policies, choosing the CPU frequency each process is dispatched at from opts.Frequencies.
+const (
+	// DVFSPerformance always runs at the fastest frequency.
+	DVFSPerformance = "performance"
+	// DVFSPowersave always runs at the slowest frequency.
+	DVFSPowersave = "powersave"
+	// DVFSOnDemand runs slower the shorter the ready queue, at the slowest frequency with only the dispatched
+	// process ready and one level faster for each process waiting behind it.
+	DVFSOnDemand = "ondemand"
+)
+
+// dvfsPolicies are the valid -dvfs-policy values.
+var dvfsPolicies = []string{DVFSPerformance, DVFSPowersave, DVFSOnDemand}
+
+// DVFSSchedule schedules processes first-come, first-serve without preemption, each running at the frequency
+// opts.DVFSPolicy chooses when it's dispatched. Frequencies are relative to full speed, so at frequency f a burst
+// takes burst/f, rounded up to a whole tick, and uses burst×f² energy, where a unit of work at full speed uses 1.
+// The processes are treated as CPU bound. Each dispatch starts opts.DispatchLatency after the process is chosen.
+// With a positive opts.MaxTime no process is dispatched from then on.
+func DVFSSchedule(title string, inputProcesses []Process, opts Options) SchedulerResult {
+	pending := make([]Process, len(inputProcesses))
+	copy(pending, inputProcesses)
+	sort.SliceStable(pending, func(a, b int) bool {
+		if pending[a].ArrivalTime == pending[b].ArrivalTime {
+			return opts.before(pending[a], pending[b], false)
+		}
+		return pending[a].ArrivalTime < pending[b].ArrivalTime
+	})
+
+	var (
+		time   int64
+		ready  = make([]Process, 0)
+		gantt  = make([]TimeSlice, 0)
+		energy float64
+	)
+	levels := opts.frequencyLevels()
+	for len(pending) > 0 || len(ready) > 0 {
+		if err := opts.cancelled(); err != nil {
+			result := calculateCompletionStats(title, cpuBound(inputProcesses), gantt)
+			result.Energy, result.Err = energy, err
+			return result
+		}
+		if until := opts.until(); until >= 0 && time >= until {
+			break
+		}
+		for len(pending) > 0 && pending[0].ArrivalTime <= time {
+			ready = append(ready, pending[0])
+			pending = pending[1:]
+		}
+		if len(ready) == 0 {
+			time = pending[0].ArrivalTime
+			continue
+		}
+
+		f := dvfsFrequency(opts.DVFSPolicy, levels, len(ready))
+		p := ready[0]
+		ready = ready[1:]
+		time += opts.DispatchLatency
+		run := int64(math.Ceil(float64(p.BurstDuration) / f))
+		gantt = append(gantt, TimeSlice{PID: p.ProcessID, Start: time, Stop: time + run})
+		energy += float64(p.BurstDuration) * f * f
+		time += run
+	}
+
+	result := calculateCompletionStats(title, cpuBound(inputProcesses), gantt)
+	result.Energy = energy
+
+	return result
+}
+
+// frequencyLevels are the options' frequencies, fastest first.
+func (o Options) frequencyLevels() []float64 {
+	levels := make([]float64, len(o.Frequencies))
+	copy(levels, o.Frequencies)
+	sort.Sort(sort.Reverse(sort.Float64Slice(levels)))
+	if len(levels) == 0 {
+		levels = append(levels, 1)
+	}
+
+	return levels
+}
+
+// dvfsFrequency is the frequency the policy runs the next process at, from levels sorted fastest first,
+// with ready processes including it.
+func dvfsFrequency(policy string, levels []float64, ready int) float64 {
+	switch policy {
+	case DVFSPerformance:
+		return levels[0]
+	case DVFSPowersave:
+		return levels[len(levels)-1]
+	default:
+		i := len(levels) - ready
+		if i < 0 {
+			i = 0
+		}
+		return levels[i]
+	}
+}
+
+// outputEnergy reports the energy a schedule used, against running all of its work at full speed.
+func outputEnergy(w io.Writer, result SchedulerResult, opts Options) {
+	var work int64
+	for _, s := range result.Stats {
+		work += s.BurstDuration
+	}
+	scale := float64(opts.ticksPerUnit())
+	_, _ = fmt.Fprintf(w, "Energy: %v (%v at full speed)\n\n",
+		formatFloat(result.Energy/scale, opts.Precision), formatFloat(float64(work)/scale, opts.Precision))
+}
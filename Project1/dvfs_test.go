@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestDVFSSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 3, ArrivalTime: 0, BurstDuration: 4},
+	}
+	tests := []struct {
+		policy       string
+		wantMakespan int64
+		wantEnergy   float64
+	}{
+		// Everything at full speed.
+		{policy: DVFSPerformance, wantMakespan: 12, wantEnergy: 12},
+		// Everything at half speed, taking twice as long for a quarter of the energy.
+		{policy: DVFSPowersave, wantMakespan: 24, wantEnergy: 3},
+		// Full speed while others wait, half speed for the last process.
+		{policy: DVFSOnDemand, wantMakespan: 16, wantEnergy: 9},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.policy, func(t *testing.T) {
+			t.Parallel()
+			opts := DefaultOptions()
+			opts.Frequencies = []float64{0.5, 1}
+			opts.DVFSPolicy = tt.policy
+			got := DVFSSchedule("DVFS", processes, opts)
+			if makespan := got.Gantt[len(got.Gantt)-1].Stop; makespan != tt.wantMakespan {
+				t.Errorf("DVFSSchedule() makespan = %v, want %v", makespan, tt.wantMakespan)
+			}
+			if got.Energy != tt.wantEnergy {
+				t.Errorf("DVFSSchedule() energy = %v, want %v", got.Energy, tt.wantEnergy)
+			}
+		})
+	}
+}
+
+func TestDVFSSchedule_stats(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 4, IO: []IORequest{{At: 1, Duration: 5}}},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+	}
+	opts := DefaultOptions()
+	opts.Frequencies = []float64{0.5}
+	got := DVFSSchedule("DVFS", processes, opts)
+	// At half speed process 1 runs 0-8 and process 2 8-12, waiting from arrival to dispatch, with I/O ignored.
+	for i, want := range []ProcessStats{
+		{Process: processes[0], Wait: 0, Turnaround: 8, Completion: 8},
+		{Process: processes[1], Wait: 7, Turnaround: 11, Completion: 12},
+	} {
+		if s := got.Stats[i]; s.Wait != want.Wait || s.Turnaround != want.Turnaround || s.Completion != want.Completion {
+			t.Errorf("DVFSSchedule() process %v stats = %+v, want %+v", s.ProcessID, s, want)
+		}
+	}
+	if got.AveWait != 3.5 || got.Throughput != 2.0/12 {
+		t.Errorf("DVFSSchedule() average wait %v and throughput %v, want 3.5 and %v", got.AveWait, got.Throughput, 2.0/12)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+)
+
+// Kinds of scheduling events.
+const (
+	EventArrive   = "arrive"
+	EventDispatch = "dispatch"
+	EventPreempt  = "preempt"
+	EventBlock    = "block"
+	EventComplete = "complete"
+)
+
+// Event is a single scheduling decision or state change, as written to the event log.
+type Event struct {
+	Time      int64  `json:"time"`
+	Event     string `json:"event"`
+	PID       int64  `json:"pid"`
+	Scheduler string `json:"scheduler"`
+}
+
+// eventOrder sorts events at the same time: the running process stops, then arrivals, then the next dispatch.
+var eventOrder = map[string]int{
+	EventPreempt:  0,
+	EventBlock:    0,
+	EventComplete: 0,
+	EventArrive:   1,
+	EventDispatch: 2,
+}
+
+// scheduleEvents derives the events of a schedule from its final gantts and stats rather than recording them as
+// the simulation runs, since not every scheduler is built on the event loop, so every scheduler's events are
+// derived the same way. A slice ending as its process starts I/O is a block, ending at the process's completion
+// is a complete, and any other slice end is a preempt. Processes left out of the stats, unfinished at the
+// -max-time cap or aborted at their deadline, have no arrive event, and their last slice ends in a preempt
+// unless it blocked for I/O.
+func scheduleEvents(result SchedulerResult) []Event {
+	events := make([]Event, 0)
+	completion := make(map[int64]int64, len(result.Stats))
+	for _, s := range result.Stats {
+		completion[s.ProcessID] = s.Completion
+		events = append(events, Event{Time: s.ArrivalTime, Event: EventArrive, PID: s.ProcessID})
+	}
+	ioStart := make(map[[2]int64]bool, len(result.IOGantt))
+	for _, s := range result.IOGantt {
+		ioStart[[2]int64{s.PID, s.Start}] = true
+	}
+
+	for _, s := range result.Gantt {
+		if s.Start == s.Stop {
+			continue
+		}
+		stop := EventPreempt
+		switch {
+		case completion[s.PID] == s.Stop:
+			stop = EventComplete
+		case ioStart[[2]int64{s.PID, s.Stop}]:
+			stop = EventBlock
+		}
+		events = append(events,
+			Event{Time: s.Start, Event: EventDispatch, PID: s.PID},
+			Event{Time: s.Stop, Event: stop, PID: s.PID},
+		)
+	}
+
+	sort.SliceStable(events, func(a, b int) bool {
+		if events[a].Time != events[b].Time {
+			return events[a].Time < events[b].Time
+		}
+		return eventOrder[events[a].Event] < eventOrder[events[b].Event]
+	})
+	for i := range events {
+		events[i].Scheduler = result.Title
+	}
+
+	return events
+}
+
+// writeEventLog writes one JSON object per line for each of the schedule's events, with times in units at the scale.
+// The events are derived from the final result, see scheduleEvents.
+func writeEventLog(w io.Writer, result SchedulerResult, scale int64) error {
+	enc := json.NewEncoder(w)
+	for _, e := range scheduleEvents(result) {
+		line := struct {
+			Time      json.Number `json:"time"`
+			Event     string      `json:"event"`
+			PID       int64       `json:"pid"`
+			Scheduler string      `json:"scheduler"`
+		}{json.Number(formatTime(e.Time, scale)), e.Event, e.PID, e.Scheduler}
+		if err := enc.Encode(line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
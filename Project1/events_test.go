@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_scheduleEvents(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		result SchedulerResult
+		want   []Event
+	}{
+		{
+			name: "round-robin preempts then completes",
+			result: RRSchedule("RR", []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+			}, DefaultOptions()),
+			want: []Event{
+				{Time: 0, Event: EventArrive, PID: 1},
+				{Time: 0, Event: EventDispatch, PID: 1},
+				{Time: 1, Event: EventArrive, PID: 2},
+				{Time: 2, Event: EventPreempt, PID: 1},
+				{Time: 2, Event: EventDispatch, PID: 2},
+				{Time: 4, Event: EventComplete, PID: 2},
+				{Time: 4, Event: EventDispatch, PID: 1},
+				{Time: 5, Event: EventComplete, PID: 1},
+			},
+		},
+		{
+			name: "I/O blocks",
+			result: FCFSIOSchedule("FCFS", []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, IO: []IORequest{{At: 1, Duration: 1}}},
+			}, DefaultOptions()),
+			want: []Event{
+				{Time: 0, Event: EventArrive, PID: 1},
+				{Time: 0, Event: EventDispatch, PID: 1},
+				{Time: 1, Event: EventBlock, PID: 1},
+				{Time: 2, Event: EventDispatch, PID: 1},
+				{Time: 3, Event: EventComplete, PID: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			for i := range tt.want {
+				tt.want[i].Scheduler = tt.result.Title
+			}
+			if got := scheduleEvents(tt.result); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("scheduleEvents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_writeEventLog(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	result := FCFSSchedule("FCFS", []Process{{ProcessID: 7, ArrivalTime: 0, BurstDuration: 2}}, DefaultOptions())
+	if err := writeEventLog(&w, result, 1); err != nil {
+		t.Fatalf("writeEventLog() unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("writeEventLog() want 3 lines, got %v", w.String())
+	}
+	if lines[0] != `{"time":0,"event":"arrive","pid":7,"scheduler":"FCFS"}` {
+		t.Errorf("writeEventLog() first line = %v", lines[0])
+	}
+	for _, line := range lines {
+		var e Event
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Errorf("writeEventLog() line isn't JSON: %v", line)
+		}
+	}
+}
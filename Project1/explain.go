@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// candidate is a ready process at the time of a dispatch, with the CPU time it still needs.
+type candidate struct {
+	Process
+	Remaining int64
+}
+
+// explainer gives the reason a scheduler dispatched the chosen process from the ready ones, chosen among them.
+type explainer func(chosen candidate, ready []candidate, opts Options) string
+
+// Decision is why a process was dispatched.
+type Decision struct {
+	Time   int64
+	PID    int64
+	Reason string
+}
+
+// among lists each ready process's value next to its PID, after the chosen one's, e.g. "2 among {3:2, 1:5}".
+func among(chosen candidate, ready []candidate, value func(candidate) string) string {
+	values := make([]string, len(ready))
+	for i, c := range ready {
+		values[i] = fmt.Sprintf("%v:%v", c.ProcessID, value(c))
+	}
+
+	return fmt.Sprintf("%v among {%v}", value(chosen), strings.Join(values, ", "))
+}
+
+// explainBy explains a choice by the lowest or highest of some value of the ready processes.
+func explainBy(criterion string, value func(candidate, Options) string) explainer {
+	return func(chosen candidate, ready []candidate, opts Options) string {
+		return criterion + " " + among(chosen, ready, func(c candidate) string { return value(c, opts) })
+	}
+}
+
+// explainQueue explains a choice by the order of the ready queue.
+func explainQueue(chosen candidate, ready []candidate, _ Options) string {
+	pids := make([]string, len(ready))
+	for i, c := range ready {
+		pids[i] = fmt.Sprint(c.ProcessID)
+	}
+
+	return fmt.Sprintf("next in the ready queue [%v]", strings.Join(pids, " "))
+}
+
+var (
+	byArrival           = func(c candidate, o Options) string { return formatTime(c.ArrivalTime, o.TimeScale) }
+	byRemaining         = func(c candidate, o Options) string { return formatTime(c.Remaining, o.TimeScale) }
+	byDeadline          = func(c candidate, o Options) string { return formatTime(c.Deadline, o.TimeScale) }
+	byPriority          = func(c candidate, _ Options) string { return fmt.Sprint(c.Priority) }
+	byWeight            = func(c candidate, _ Options) string { return fmt.Sprint(c.weight()) }
+	byRemainingPriority = func(c candidate, o Options) string {
+		return fmt.Sprintf("%v (priority %v)", formatTime(c.Remaining, o.TimeScale), c.Priority)
+	}
+	byValue = func(c candidate, o Options) string {
+		return fmt.Sprintf("%v over %v", c.Value, formatTime(c.Remaining, o.TimeScale))
+	}
+)
+
+// The schedulers' explainers, see algorithms.
+var (
+	explainArrival   = explainBy("earliest arrival", byArrival)
+	explainLatest    = explainBy("latest arrival", byArrival)
+	explainRemaining = explainBy("shortest remaining burst", byRemaining)
+	explainPriority  = explainBy("shortest remaining burst, then highest priority", byRemainingPriority)
+	explainDeadline  = explainBy("earliest deadline", byDeadline)
+	explainTickets   = explainBy("lowest pass for its tickets", byWeight)
+	explainClass     = explainBy("highest priority class", byPriority)
+	explainShare     = explainBy("furthest behind the share promised by its weight", byWeight)
+	explainUtility   = explainBy("highest utility per remaining time", byValue)
+)
+
+// explainDecisions replays the schedule, explaining every dispatch from the processes ready at the time.
+func explainDecisions(result SchedulerResult, explain explainer, opts Options) []Decision {
+	processes := make(map[int64]Process, len(result.Stats))
+	for _, s := range result.Stats {
+		processes[s.ProcessID] = s.Process
+	}
+	remaining := func(pid, time int64) int64 {
+		left := processes[pid].BurstDuration
+		for _, s := range result.Gantt {
+			if s.PID != pid || s.Start >= time {
+				continue
+			}
+			stop := s.Stop
+			if stop > time {
+				stop = time
+			}
+			left -= stop - s.Start
+		}
+		return left
+	}
+
+	decisions := make([]Decision, 0)
+	m := newTUIModel(result)
+	for m.next < len(m.events) {
+		if e := m.events[m.next]; e.Event == EventDispatch {
+			ready := make([]candidate, len(m.ready))
+			var chosen candidate
+			for i, pid := range m.ready {
+				ready[i] = candidate{Process: processes[pid], Remaining: remaining(pid, e.Time)}
+				if pid == e.PID {
+					chosen = ready[i]
+				}
+			}
+			decisions = append(decisions, Decision{Time: e.Time, PID: e.PID, Reason: explain(chosen, ready, opts)})
+		}
+		m.apply()
+	}
+
+	return decisions
+}
+
+func outputDecisions(w io.Writer, decisions []Decision, opts Options) {
+	_, _ = fmt.Fprintln(w, "Decisions")
+	for _, d := range decisions {
+		_, _ = fmt.Fprintf(w, "%v: selected PID %v: %v\n", formatTime(d.Time, opts.TimeScale), d.PID, d.Reason)
+	}
+	_, _ = fmt.Fprintln(w)
+}
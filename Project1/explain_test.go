@@ -0,0 +1,29 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_explainDecisions(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 3, ArrivalTime: 0, BurstDuration: 1},
+	}
+	result := SJFSchedule("Shortest-job-first", processes, DefaultOptions())
+	var w bytes.Buffer
+	outputDecisions(&w, explainDecisions(result, explainRemaining, DefaultOptions()), DefaultOptions())
+
+	for _, want := range []string{
+		"0: selected PID 3: shortest remaining burst 1 among {1:6, 2:3, 3:1}",
+		"1: selected PID 2: shortest remaining burst 3 among {1:6, 2:3}",
+		"4: selected PID 1: shortest remaining burst 6 among {1:6}",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputDecisions() missing %q, got:\n%v", want, w.String())
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// exportFields are the process as a row of csvColumns, at the time scale, with the default weight of 1 filled in.
+// Nice is left empty, as it's exported as the weight it was read as.
+func exportFields(p Process, scale int64) []string {
+	io := make([]string, len(p.IO))
+	for i, r := range p.IO {
+		io[i] = formatTime(r.At, scale) + ":" + formatTime(r.Duration, scale)
+	}
+
+	return []string{
+		fmt.Sprint(p.ProcessID), formatTime(p.BurstDuration, scale), formatTime(p.ArrivalTime, scale),
+		fmt.Sprint(p.Priority), fmt.Sprint(p.Affinity), formatTime(p.Deadline, scale), strings.Join(io, " "),
+		formatTime(p.Period, scale), fmt.Sprint(p.weight()), fmt.Sprint(p.Batch), fmt.Sprint(p.Instances),
+		"", fmt.Sprint(p.Value),
+	}
+}
+
+// exportProcesses writes the processes back out in the input format, CSV or JSON, in canonical form: every column
+// in the default order of csvColumns, or every JSON member, so loading the export gives the same processes.
+func exportProcesses(w io.Writer, processes []Process, format string, scale int64) error {
+	if format == InputJSON {
+		records := make([]jsonProcess, len(processes))
+		for i, p := range processes {
+			f := exportFields(p, scale)
+			records[i] = jsonProcess{
+				ID: json.Number(f[0]), Burst: json.Number(f[1]), Arrival: json.Number(f[2]),
+				Priority: json.Number(f[3]), Affinity: json.Number(f[4]), Deadline: json.Number(f[5]), IO: f[6],
+				Period: json.Number(f[7]), Weight: json.Number(f[8]), Batch: json.Number(f[9]), Instances: json.Number(f[10]),
+				Value: json.Number(f[12]),
+			}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	}
+
+	cw := csv.NewWriter(w)
+	for _, p := range processes {
+		if err := cw.Write(exportFields(p, scale)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
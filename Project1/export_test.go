@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_exportProcesses(t *testing.T) {
+	t.Parallel()
+	columns, err := parseColumns("arrival, burst, id, io, weight")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spacey := " 0 , 4.5 , 1 , 1:2 , 3 \n2,  1,2,, 0\n"
+	processes, scale, _, err := readProcesses(strings.NewReader(spacey), columns, nil)
+	if err != nil {
+		t.Fatalf("readProcesses() error = %v", err)
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: InputCSV, want: "1,4.5,0,0,0,0,1:2,0,3,0,0,,0\n2,1,2,0,0,0,,0,1,0,0,,0\n"},
+		{format: InputJSON, want: `"id": 2,
+    "burst": 1,
+    "arrival": 2,
+    "priority": 0,
+    "affinity": 0,
+    "deadline": 0,
+    "io": "",
+    "period": 0,
+    "weight": 1,
+    "batch": 0,
+    "instances": 0,
+    "value": 0
+  }`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.format, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			if err := exportProcesses(&w, processes, tt.format, scale); err != nil {
+				t.Fatalf("exportProcesses() error = %v", err)
+			}
+			if !strings.Contains(w.String(), tt.want) {
+				t.Errorf("exportProcesses() = %v, want %v", w.String(), tt.want)
+			}
+
+			// Loading the export gives back the processes, with the default weight filled in.
+			reloaded, reloadedScale, _, err := loadInput(&w, tt.format, nil, nil)
+			if err != nil {
+				t.Fatalf("loadInput() error = %v", err)
+			}
+			want := append([]Process(nil), processes...)
+			want[1].Weight = 1
+			if reloadedScale != scale || !reflect.DeepEqual(reloaded, want) {
+				t.Errorf("loadInput() = %+v at scale %v, want %+v at scale %v", reloaded, reloadedScale, want, scale)
+			}
+		})
+	}
+}
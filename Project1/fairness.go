@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// cpuShares is the fraction of its time in the system each process spent on the CPU, summed from the gantt,
+// in the order of the result's stats.
+func cpuShares(result SchedulerResult) []float64 {
+	ran := make(map[int64]int64, len(result.Stats))
+	for _, s := range result.Gantt {
+		ran[s.PID] += s.Stop - s.Start
+	}
+	shares := make([]float64, len(result.Stats))
+	for i, s := range result.Stats {
+		if s.Turnaround > 0 {
+			shares[i] = float64(ran[s.ProcessID]) / float64(s.Turnaround)
+		}
+	}
+
+	return shares
+}
+
+// jainIndex is Jain's fairness index of the allocations, from 1/n when one gets everything to 1 when all are equal.
+func jainIndex(xs []float64) float64 {
+	var sum, squares float64
+	for _, x := range xs {
+		sum += x
+		squares += x * x
+	}
+	if squares == 0 {
+		return 1
+	}
+
+	return sum * sum / (float64(len(xs)) * squares)
+}
+
+// gini is the Gini coefficient of the allocations, 0 when all are equal and approaching 1 as one gets everything.
+func gini(xs []float64) float64 {
+	var sum, differences float64
+	for _, x := range xs {
+		sum += x
+		for _, y := range xs {
+			if x > y {
+				differences += x - y
+			} else {
+				differences += y - x
+			}
+		}
+	}
+	if sum == 0 {
+		return 0
+	}
+
+	return differences / (2 * float64(len(xs)) * sum)
+}
+
+// outputFairness compares the schedules by how evenly their processes shared the CPU.
+func outputFairness(w io.Writer, results []SchedulerResult, opts Options) {
+	_, _ = fmt.Fprintln(w, "Fairness of CPU shares")
+	table := newTable(w, opts)
+	table.SetHeader([]string{"Scheduler", "Jain's index", "Gini"})
+	for _, r := range results {
+		shares := cpuShares(r)
+		table.Append([]string{
+			r.Title,
+			formatFloat(jainIndex(shares), opts.Precision),
+			formatFloat(gini(shares), opts.Precision),
+		})
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_gini(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		schedule  Scheduler
+		wantGini  float64
+		wantJain  float64
+	}{
+		{
+			name: "egalitarian",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 6},
+				{ProcessID: 2, BurstDuration: 6},
+				{ProcessID: 3, BurstDuration: 6},
+			},
+			schedule: RRSchedule,
+			wantGini: 0.05,
+			wantJain: 1,
+		},
+		{
+			// The long job runs at once while every short job waits almost its whole turnaround behind it.
+			name: "monopolized",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 1000},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1},
+				{ProcessID: 3, ArrivalTime: 1, BurstDuration: 1},
+				{ProcessID: 4, ArrivalTime: 1, BurstDuration: 1},
+				{ProcessID: 5, ArrivalTime: 1, BurstDuration: 1},
+				{ProcessID: 6, ArrivalTime: 1, BurstDuration: 1},
+				{ProcessID: 7, ArrivalTime: 1, BurstDuration: 1},
+				{ProcessID: 8, ArrivalTime: 1, BurstDuration: 1},
+				{ProcessID: 9, ArrivalTime: 1, BurstDuration: 1},
+				{ProcessID: 10, ArrivalTime: 1, BurstDuration: 1},
+			},
+			schedule: LCFSSchedule,
+			wantGini: 0.9,
+			wantJain: 0.1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			shares := cpuShares(tt.schedule(tt.name, tt.processes, DefaultOptions()))
+			if got := gini(shares); math.Abs(got-tt.wantGini) > 0.03 {
+				t.Errorf("gini() = %v, want about %v", got, tt.wantGini)
+			}
+			if got := jainIndex(shares); math.Abs(got-tt.wantJain) > 0.03 {
+				t.Errorf("jainIndex() = %v, want about %v", got, tt.wantJain)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+// FairShareSchedule promises every process present a share of the CPU in proportion to its weight, read from Weight
+// where a bigger number is a bigger weight, and each tick runs whichever ready process is furthest behind the CPU time
+// it was entitled to so far. Processes are only entitled to CPU while they're ready, so a late arrival isn't owed the
+// time before it arrived. Ties go by opts.TieBreak.
+func FairShareSchedule(title string, inputProcesses []Process, opts Options) SchedulerResult {
+	weight := func(p Process) float64 {
+		return float64(p.weight())
+	}
+	var (
+		entitled = make(map[int64]float64, len(inputProcesses))
+		actual   = make(map[int64]float64, len(inputProcesses))
+		// present and picked are the ready processes and the one picked for the tick that just ran.
+		present []Process
+		picked  int64 = -1
+		last    int64 = -1
+	)
+
+	gantt, ioGantt, err := preemptiveSchedule(inputProcesses, 1, opts, func(ready []*runnable, time int64) int {
+		if last >= 0 && time > last {
+			var total float64
+			for _, p := range present {
+				total += weight(p)
+			}
+			for _, p := range present {
+				entitled[p.ProcessID] += weight(p) / total
+			}
+			actual[picked]++
+		}
+
+		best := 0
+		for i := 1; i < len(ready); i++ {
+			behind := entitled[ready[i].ProcessID] - actual[ready[i].ProcessID]
+			bestBehind := entitled[ready[best].ProcessID] - actual[ready[best].ProcessID]
+			if behind > bestBehind || (behind == bestBehind && opts.before(ready[i].Process, ready[best].Process, false)) {
+				best = i
+			}
+		}
+
+		present = present[:0]
+		for _, r := range ready {
+			present = append(present, r.Process)
+		}
+		picked, last = ready[best].ProcessID, time
+
+		return best
+	})
+	result := calculateCompletionStats(title, inputProcesses, gantt)
+	result.IOGantt = ioGantt
+	result.Err = err
+
+	return result
+}
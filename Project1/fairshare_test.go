@@ -0,0 +1,58 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFairShareSchedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		window    int64
+		want      map[int64]float64
+	}{
+		{
+			name: "equal weights",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 50, Weight: 1},
+				{ProcessID: 2, BurstDuration: 50, Weight: 1},
+				{ProcessID: 3, BurstDuration: 50, Weight: 1},
+			},
+			window: 60,
+			want:   map[int64]float64{1: 1.0 / 3, 2: 1.0 / 3, 3: 1.0 / 3},
+		},
+		{
+			name: "weighted",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 50, Weight: 1},
+				{ProcessID: 2, BurstDuration: 50, Weight: 3},
+			},
+			window: 40,
+			want:   map[int64]float64{1: 0.25, 2: 0.75},
+		},
+		{
+			// The late arrival is only owed its share from when it arrived, so it doesn't monopolize the CPU catching up.
+			name: "late arrival",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 50, Weight: 1},
+				{ProcessID: 2, ArrivalTime: 20, BurstDuration: 50, Weight: 1},
+			},
+			window: 40,
+			want:   map[int64]float64{1: 0.75, 2: 0.25},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := cpuTime(FairShareSchedule("Fair-share", tt.processes, DefaultOptions()).Gantt, tt.window)
+			for pid, share := range tt.want {
+				if actual := float64(got[pid]) / float64(tt.window); math.Abs(actual-share) > 0.05 {
+					t.Errorf("FairShareSchedule() process %v got %v of the CPU, want about %v", pid, actual, share)
+				}
+			}
+		})
+	}
+}
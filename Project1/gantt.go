@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// ganttWidth is the number of columns the scaled gantt chart spans, however long the schedule,
+// unless -gantt-scale sets the columns per unit of time.
+const ganttWidth = 60
+
+// ganttSymbols fill the gantt's bars so processes can be told apart without color, see ganttSymbol.
+const ganttSymbols = "#*+=%@o~x&"
+
+// ganttSymbol is the fill symbol of a PID, always the same for the same PID whatever else is scheduled.
+func ganttSymbol(pid int64) byte {
+	i := (pid - 1) % int64(len(ganttSymbols))
+	if i < 0 {
+		i += int64(len(ganttSymbols))
+	}
+
+	return ganttSymbols[i]
+}
+
+// Justifications of the PIDs in the text gantt's cells.
+const (
+	JustifyCenter = "center"
+	JustifyLeft   = "left"
+	JustifyRight  = "right"
+)
+
+// ganttCellWidth is the width of the text gantt's cells, less the border, that PIDs are centered in.
+// Longer PIDs widen their cell to keep a fill either side, so no cell is narrower than its PID plus two.
+const ganttCellWidth = 8
+
+// ganttCell is a text gantt cell holding the PID, justified with the fill, without its border.
+// Every PID of the same length gets the same width of cell whatever the justification.
+func ganttCell(pid, fill, justify string) string {
+	padding := (ganttCellWidth - len(pid)) / 2
+	if padding < 1 {
+		padding = 1
+	}
+	switch justify {
+	case JustifyLeft:
+		return fill + pid + repeat(fill, 2*padding-1)
+	case JustifyRight:
+		return repeat(fill, 2*padding-1) + pid + fill
+	default:
+		return repeat(fill, padding) + pid + repeat(fill, padding)
+	}
+}
+
+// compactGantt encodes the gantt with its idle slices on a single line, e.g. "|1:0-5|idle:5-7|2:7-12|",
+// with times at the scale.
+func compactGantt(gantt, idle []TimeSlice, scale int64) string {
+	var b strings.Builder
+	b.WriteString("|")
+	for i := range gantt {
+		for len(idle) > 0 && idle[0].Stop <= gantt[i].Start {
+			fmt.Fprintf(&b, "idle:%v-%v|", formatTime(idle[0].Start, scale), formatTime(idle[0].Stop, scale))
+			idle = idle[1:]
+		}
+		fmt.Fprintf(&b, "%v:%v-%v|", gantt[i].PID, formatTime(gantt[i].Start, scale), formatTime(gantt[i].Stop, scale))
+	}
+
+	return b.String()
+}
+
+// repeat is strings.Repeat, but a count below zero, from a label wider than the space measured for it,
+// gives nothing instead of panicking.
+func repeat(s string, count int) string {
+	if count < 0 {
+		return ""
+	}
+
+	return strings.Repeat(s, count)
+}
+
+// ganttMakespan is when the last slice of the gantt stops.
+func ganttMakespan(gantt []TimeSlice) int64 {
+	var makespan int64
+	for _, s := range gantt {
+		if s.Stop > makespan {
+			makespan = s.Stop
+		}
+	}
+
+	return makespan
+}
+
+// tickInterval is the requested interval, or without one the smallest 1, 2 or 5 times a power of ten
+// giving no more than 10 ticks across the makespan.
+func tickInterval(makespan, requested int64) int64 {
+	if requested > 0 {
+		return requested
+	}
+	for magnitude := int64(1); ; magnitude *= 10 {
+		for _, step := range []int64{1, 2, 5} {
+			if interval := step * magnitude; makespan <= interval*10 {
+				return interval
+			}
+		}
+	}
+}
+
+// ganttTicks are the times from zero to the makespan at every interval.
+func ganttTicks(makespan, interval int64) []int64 {
+	ticks := make([]int64, 0)
+	for t := int64(0); t <= makespan; t += interval {
+		ticks = append(ticks, t)
+	}
+
+	return ticks
+}
+
+// ganttColumns is how many columns the scaled chart of the makespan spans, at least one.
+func (o Options) ganttColumns(makespan int64) int {
+	if o.GanttScale <= 0 {
+		return ganttWidth
+	}
+	columns := int(math.Round(float64(makespan) / float64(o.ticksPerUnit()) * o.GanttScale))
+	if columns < 1 {
+		return 1
+	}
+
+	return columns
+}
+
+// ganttColumn is the column of a chart of the given width a time falls in. Every slice's width is the difference
+// of its ends' columns, so however they round the widths always add up to the chart's.
+func ganttColumn(t, makespan int64, width int) int {
+	return int(t * int64(width) / makespan)
+}
+
+// ganttLegend is the symbol of each process in the gantt, with the processes in the order they first run.
+func ganttLegend(gantt []TimeSlice) ([]int64, map[int64]byte) {
+	order := make([]int64, 0)
+	symbols := make(map[int64]byte)
+	for _, s := range gantt {
+		if _, ok := symbols[s.PID]; !ok {
+			symbols[s.PID] = ganttSymbol(s.PID)
+			order = append(order, s.PID)
+		}
+	}
+
+	return order, symbols
+}
+
+func outputGanttLegend(w io.Writer, gantt []TimeSlice) {
+	order, symbols := ganttLegend(gantt)
+	legend := make([]string, len(order))
+	for i, pid := range order {
+		legend[i] = fmt.Sprintf("%c = %v", symbols[pid], pid)
+	}
+	_, _ = fmt.Fprintf(w, "Legend: %v\n\n", strings.Join(legend, ", "))
+}
+
+// outputGanttAxis draws the gantt to scale with a symbol per process, a legend, and a time axis with tick marks.
+func outputGanttAxis(w io.Writer, gantt []TimeSlice, opts Options) {
+	makespan := ganttMakespan(gantt)
+	if makespan == 0 {
+		return
+	}
+	_, symbols := ganttLegend(gantt)
+
+	width := opts.ganttColumns(makespan)
+	bars := []byte(repeat(" ", width))
+	for _, s := range gantt {
+		for c := ganttColumn(s.Start, makespan, width); c < ganttColumn(s.Stop, makespan, width); c++ {
+			bars[c] = symbols[s.PID]
+		}
+	}
+	axis := []byte(repeat("-", width+1))
+	// The last label can run past the axis' end.
+	labels := []byte(repeat(" ", width+1+len(formatTime(makespan, opts.TimeScale))))
+	free := 0
+	for _, t := range ganttTicks(makespan, tickInterval(makespan, opts.GanttTicks)) {
+		c := ganttColumn(t, makespan, width)
+		axis[c] = '+'
+		label := formatTime(t, opts.TimeScale)
+		if c < free {
+			continue
+		}
+		copy(labels[c:], label)
+		free = c + len(label) + 1
+	}
+
+	_, _ = fmt.Fprintf(w, "|%s|\n", bars)
+	_, _ = fmt.Fprintf(w, "%s\n", axis)
+	_, _ = fmt.Fprintf(w, "%s\n", strings.TrimRight(string(labels), " "))
+	if opts.Monochrome {
+		// The plain gantt above already has the legend.
+		_, _ = fmt.Fprintln(w)
+		return
+	}
+	outputGanttLegend(w, gantt)
+}
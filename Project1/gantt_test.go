@@ -0,0 +1,260 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_tickInterval(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name                string
+		makespan, requested int64
+		want                int64
+	}{
+		{name: "short", makespan: 7, want: 1},
+		{name: "twenty", makespan: 20, want: 2},
+		{name: "thirty seven", makespan: 37, want: 5},
+		{name: "long", makespan: 450, want: 50},
+		{name: "requested", makespan: 450, requested: 25, want: 25},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tickInterval(tt.makespan, tt.requested); got != tt.want {
+				t.Errorf("tickInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputGanttAxis(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 5},
+		{PID: 2, Start: 5, Stop: 14},
+		{PID: 3, Start: 14, Stop: 20},
+	}
+	if got, want := ganttTicks(20, tickInterval(20, 0)), []int64{0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ganttTicks() = %v, want %v", got, want)
+	}
+
+	opts := DefaultOptions()
+	opts.GanttTicks = 5
+	var w bytes.Buffer
+	outputGanttAxis(&w, gantt, opts)
+	lines := strings.Split(w.String(), "\n")
+	wantLines := []string{
+		"|" + strings.Repeat("#", 15) + strings.Repeat("*", 27) + strings.Repeat("+", 18) + "|",
+		"+" + strings.Repeat("-", 14) + "+" + strings.Repeat("-", 14) + "+" + strings.Repeat("-", 14) + "+" + strings.Repeat("-", 14) + "+",
+		"0" + strings.Repeat(" ", 14) + "5" + strings.Repeat(" ", 14) + "10" + strings.Repeat(" ", 13) + "15" + strings.Repeat(" ", 13) + "20",
+		"Legend: # = 1, * = 2, + = 3",
+	}
+	for i, want := range wantLines {
+		if lines[i] != want {
+			t.Errorf("outputGanttAxis() line %v = %q, want %q", i, lines[i], want)
+		}
+	}
+}
+
+func Test_ganttSymbol(t *testing.T) {
+	t.Parallel()
+	// A PID keeps its symbol whatever else is scheduled, and in whatever order.
+	_, first := ganttLegend([]TimeSlice{{PID: 3}, {PID: 1}})
+	_, second := ganttLegend([]TimeSlice{{PID: 1}, {PID: 7}, {PID: 3}})
+	if first[3] != second[3] || first[1] != second[1] {
+		t.Errorf("ganttLegend() symbols changed between schedules: %v then %v", first, second)
+	}
+	if ganttSymbol(1) == ganttSymbol(2) {
+		t.Errorf("ganttSymbol() gave PIDs 1 and 2 the same symbol %c", ganttSymbol(1))
+	}
+	if ganttSymbol(11) != ganttSymbol(1) || ganttSymbol(0) != ganttSymbol(10) {
+		t.Errorf("ganttSymbol() should cycle through %q", ganttSymbols)
+	}
+}
+
+func Test_outputGantt_monochrome(t *testing.T) {
+	t.Parallel()
+	opts := DefaultOptions()
+	opts.Monochrome = true
+	var w bytes.Buffer
+	outputGantt(&w, "Gantt schedule", []TimeSlice{{PID: 2, Start: 0, Stop: 3}, {PID: 1, Start: 3, Stop: 5}}, opts)
+	want := "Gantt schedule\n|***2***|###1###|\n0\t3\t5\n\nLegend: * = 2, # = 1\n\n"
+	if got := w.String(); got != want {
+		t.Errorf("outputGantt() = %q, want %q", got, want)
+	}
+}
+
+func Test_outputGanttAxis_scale(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 4},
+		{PID: 2, Start: 4, Stop: 10},
+		{PID: 3, Start: 10, Stop: 20},
+	}
+	// bars draws the gantt at the scale, returning its bar line.
+	bars := func(scale float64) string {
+		opts := DefaultOptions()
+		opts.GanttScale = scale
+		var w bytes.Buffer
+		outputGanttAxis(&w, gantt, opts)
+		return strings.SplitN(w.String(), "\n", 2)[0]
+	}
+	tests := []struct {
+		scale float64
+		want  string
+	}{
+		{scale: 2, want: "|########************++++++++++++++++++++|"},
+		{scale: 1, want: "|####******++++++++++|"},
+		// Half a column per unit merges every two units into one.
+		{scale: 0.5, want: "|##***+++++|"},
+	}
+	for _, tt := range tests {
+		if got := bars(tt.scale); got != tt.want {
+			t.Errorf("outputGanttAxis() at scale %v = %v, want %v", tt.scale, got, tt.want)
+		}
+	}
+	// Each process keeps its share of the width at every scale.
+	wide, narrow := bars(2), bars(1)
+	for _, symbol := range "#*+" {
+		if strings.Count(wide, string(symbol)) != 2*strings.Count(narrow, string(symbol)) {
+			t.Errorf("outputGanttAxis() %c is %v at scale 2 but %v at scale 1", symbol,
+				strings.Count(wide, string(symbol)), strings.Count(narrow, string(symbol)))
+		}
+	}
+}
+
+func Test_outputGantt_wrapped(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 2, Start: 2, Stop: 5},
+		{PID: 3, Start: 5, Stop: 6},
+		{PID: 1, Start: 6, Stop: 9},
+	}
+	opts := DefaultOptions()
+	// Room for the opening "|" and two 8 column cells, but not three.
+	opts.Width = 20
+	var w bytes.Buffer
+	outputGantt(&w, "Gantt schedule", gantt, opts)
+	want := "Gantt schedule\n" +
+		"|   1   |   2   |\n0\t2\t5\n\n" +
+		"|   3   |   1   |\n5\t6\t9\n\n"
+	if w.String() != want {
+		t.Errorf("outputGantt() = %q, want %q", w.String(), want)
+	}
+}
+
+func Test_ganttBands(t *testing.T) {
+	t.Parallel()
+	cells := []string{"   1   |", "   2   |", "   3   |"}
+	tests := []struct {
+		name  string
+		width int
+		want  [][2]int
+	}{
+		{name: "no wrapping", width: 0, want: [][2]int{{0, 3}}},
+		{name: "exactly fits", width: 25, want: [][2]int{{0, 3}}},
+		{name: "two bands", width: 24, want: [][2]int{{0, 2}, {2, 3}}},
+		{name: "a cell per band however narrow", width: 3, want: [][2]int{{0, 1}, {1, 2}, {2, 3}}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ganttBands(cells, tt.width); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ganttBands() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputGantt_justify(t *testing.T) {
+	t.Parallel()
+	// PIDs from one digit to wider than a cell.
+	gantt := []TimeSlice{
+		{PID: 7, Start: 0, Stop: 1},
+		{PID: 123, Start: 1, Stop: 2},
+		{PID: 12345678901, Start: 2, Stop: 3},
+	}
+	tests := []struct {
+		justify string
+		want    string
+	}{
+		{justify: JustifyCenter, want: "|   7   |  123  | 12345678901 |\n"},
+		{justify: JustifyLeft, want: "| 7     | 123   | 12345678901 |\n"},
+		{justify: JustifyRight, want: "|     7 |   123 | 12345678901 |\n"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.justify, func(t *testing.T) {
+			t.Parallel()
+			opts := DefaultOptions()
+			opts.GanttJustify = tt.justify
+			var w bytes.Buffer
+			outputGantt(&w, "Gantt schedule", gantt, opts)
+			if !strings.Contains(w.String(), tt.want) {
+				t.Errorf("outputGantt() %v = %q, want cells %q", tt.justify, w.String(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputGantt_widePID(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 123456789, Start: 4, Stop: 6},
+	}
+	for _, mono := range []bool{false, true} {
+		opts := DefaultOptions()
+		opts.Monochrome, opts.GanttAxis, opts.Width = mono, true, 20
+		var w bytes.Buffer
+		outputTitle(&w, "Process 123456789")
+		outputGantt(&w, "Gantt schedule", gantt, opts)
+		cell := " 123456789 |"
+		if mono {
+			cell = "x123456789x|"
+		}
+		if !strings.Contains(w.String(), cell) {
+			t.Errorf("outputGantt() mono %v = %q, want the wide PID in a cell %q", mono, w.String(), cell)
+		}
+	}
+}
+
+func Test_repeat(t *testing.T) {
+	t.Parallel()
+	if got := repeat("#", 3); got != "###" {
+		t.Errorf("repeat() = %q, want %q", got, "###")
+	}
+	if got := repeat("#", -2); got != "" {
+		t.Errorf("repeat() negative count = %q, want nothing", got)
+	}
+}
+
+func Test_compactGantt(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 5},
+		{PID: 2, Start: 7, Stop: 12},
+		{PID: 3, Start: 12, Stop: 15},
+	}
+	if got, want := compactGantt(gantt, idleSlices(gantt), 1), "|1:0-5|idle:5-7|2:7-12|3:12-15|"; got != want {
+		t.Errorf("compactGantt() = %q, want %q", got, want)
+	}
+	// Times are in the input's units.
+	if got, want := compactGantt(gantt[:1], nil, 10), "|1:0-0.5|"; got != want {
+		t.Errorf("compactGantt() at a scale of 10 = %q, want %q", got, want)
+	}
+
+	opts := DefaultOptions()
+	opts.CompactGantt = true
+	var w bytes.Buffer
+	outputGantt(&w, "Gantt schedule", gantt, opts)
+	if want := "Gantt schedule\n|1:0-5|idle:5-7|2:7-12|3:12-15|\n\n"; w.String() != want {
+		t.Errorf("outputGantt() compact = %q, want %q", w.String(), want)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// idlePID is the PID of the idle slices in an exported gantt, which no process can have as IDs are positive.
+const idlePID int64 = -1
+
+// ganttCSVHeader are the columns of an exported gantt, the core column only for multicore schedules.
+func ganttCSVHeader(cores int) []string {
+	header := []string{"scheduler", "pid", "start", "stop"}
+	if cores > 1 {
+		header = append(header, "core")
+	}
+
+	return header
+}
+
+// writeGanttCSV writes a row for each of the result's slices, and for each gap its cores sat idle with idlePID,
+// in order of start time and then core. With header the column names come first.
+func writeGanttCSV(w io.Writer, result SchedulerResult, opts Options, header bool) error {
+	cores := result.Cores
+	if cores < 1 {
+		cores = 1
+	}
+	slices := make([]TimeSlice, 0, len(result.Gantt))
+	for core := 0; core < cores; core++ {
+		gantt := coreGantt(result.Gantt, core)
+		slices = append(slices, gantt...)
+		for _, s := range splitIdle(idleSlices(gantt), append(result.Gantt, result.IOGantt...), opts) {
+			s.PID, s.Core = idlePID, core
+			slices = append(slices, s)
+		}
+	}
+	sort.SliceStable(slices, func(a, b int) bool {
+		if slices[a].Start == slices[b].Start {
+			return slices[a].Core < slices[b].Core
+		}
+		return slices[a].Start < slices[b].Start
+	})
+
+	cw := csv.NewWriter(w)
+	if header {
+		if err := cw.Write(ganttCSVHeader(cores)); err != nil {
+			return err
+		}
+	}
+	for _, s := range slices {
+		row := []string{result.Title, fmt.Sprint(s.PID), formatTime(s.Start, opts.TimeScale), formatTime(s.Stop, opts.TimeScale)}
+		if cores > 1 {
+			row = append(row, fmt.Sprint(s.Core))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+)
+
+func Test_writeGanttCSV(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		result SchedulerResult
+		want   [][]string
+	}{
+		{
+			name: "single core",
+			result: SchedulerResult{
+				Title: "FCFS",
+				Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 5, Stop: 7}},
+			},
+			want: [][]string{
+				{"scheduler", "pid", "start", "stop"},
+				{"FCFS", "1", "0", "3"},
+				{"FCFS", "-1", "3", "5"},
+				{"FCFS", "2", "5", "7"},
+			},
+		},
+		{
+			name: "multicore",
+			result: SchedulerResult{
+				Title: "RR",
+				Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 4, Core: 0}, {PID: 2, Start: 2, Stop: 4, Core: 1}},
+				Cores: 2,
+			},
+			want: [][]string{
+				{"scheduler", "pid", "start", "stop", "core"},
+				{"RR", "1", "0", "4", "0"},
+				{"RR", "-1", "0", "2", "1"},
+				{"RR", "2", "2", "4", "1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			if err := writeGanttCSV(&w, tt.result, DefaultOptions(), true); err != nil {
+				t.Fatalf("writeGanttCSV() error = %v", err)
+			}
+			got, err := csv.NewReader(&w).ReadAll()
+			if err != nil {
+				t.Fatalf("writeGanttCSV() wrote invalid CSV: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("writeGanttCSV() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
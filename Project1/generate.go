@@ -0,0 +1,40 @@
+package main
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// Bounds of the random workloads generateWorkload makes.
+const (
+	generatedProcesses  = 8
+	generatedMaxBurst   = 10
+	generatedMaxArrival = 20
+	generatedPriorities = 3
+)
+
+// newSeededRand is a random source that repeats the same workloads for the same seed.
+func newSeededRand(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
+// generateWorkload makes a random workload of CPU bound processes, sorted by arrival time as main sorts a file.
+func generateWorkload(rng *rand.Rand) []Process {
+	arrivals := make([]int64, generatedProcesses)
+	for i := range arrivals {
+		arrivals[i] = rng.Int63n(generatedMaxArrival + 1)
+	}
+	sort.Slice(arrivals, func(a, b int) bool { return arrivals[a] < arrivals[b] })
+
+	processes := make([]Process, generatedProcesses)
+	for i := range processes {
+		processes[i] = Process{
+			ProcessID:     int64(i + 1),
+			ArrivalTime:   arrivals[i],
+			BurstDuration: 1 + rng.Int63n(generatedMaxBurst),
+			Priority:      1 + rng.Int63n(generatedPriorities),
+		}
+	}
+
+	return processes
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Fields the schedule table can be grouped by.
+const (
+	GroupByPriority = "priority"
+	GroupByCore     = "core"
+	GroupByBatch    = "batch"
+)
+
+// groupFields are the valid -group-by values.
+var groupFields = []string{GroupByPriority, GroupByCore, GroupByBatch}
+
+// completionCore is the core each process completed on, the core of its last slice.
+func completionCore(gantt []TimeSlice) map[int64]int {
+	cores := make(map[int64]int)
+	last := make(map[int64]int64)
+	for _, s := range gantt {
+		if stop, ok := last[s.PID]; !ok || s.Stop >= stop {
+			cores[s.PID], last[s.PID] = s.Core, s.Stop
+		}
+	}
+
+	return cores
+}
+
+// ScheduleGroup is the indexes into a result's stats of the processes sharing one value of the -group-by field.
+type ScheduleGroup struct {
+	Key     int64
+	Members []int
+}
+
+// scheduleGroups groups the result's processes by the field, keeping their order within each group. Groups are
+// in order of their key, priorities highest first in the options' priority order.
+func scheduleGroups(result SchedulerResult, field string, opts Options) []ScheduleGroup {
+	cores := completionCore(result.Gantt)
+	byKey := make(map[int64]*ScheduleGroup)
+	groups := make([]*ScheduleGroup, 0)
+	for i, s := range result.Stats {
+		var key int64
+		switch field {
+		case GroupByPriority:
+			key = s.Priority
+		case GroupByCore:
+			key = int64(cores[s.ProcessID])
+		case GroupByBatch:
+			key = s.Batch
+		}
+		g, ok := byKey[key]
+		if !ok {
+			g = &ScheduleGroup{Key: key}
+			byKey[key] = g
+			groups = append(groups, g)
+		}
+		g.Members = append(g.Members, i)
+	}
+	sort.SliceStable(groups, func(a, b int) bool {
+		if field == GroupByPriority {
+			return opts.higherPriority(groups[a].Key, groups[b].Key)
+		}
+		return groups[a].Key < groups[b].Key
+	})
+
+	sorted := make([]ScheduleGroup, len(groups))
+	for i := range groups {
+		sorted[i] = *groups[i]
+	}
+
+	return sorted
+}
+
+// groupLabel heads a group in the schedule table, e.g. "Priority 2", with "No batch" for processes in none.
+func groupLabel(field string, key int64) string {
+	switch {
+	case field == GroupByBatch && key == 0:
+		return "No batch"
+	case field == GroupByBatch:
+		return fmt.Sprintf("Batch %v", key)
+	case field == GroupByCore:
+		return fmt.Sprintf("Core %v", key)
+	default:
+		return fmt.Sprintf("Priority %v", key)
+	}
+}
+
+// outputGroupedSchedule is outputSchedule with the processes grouped by opts.GroupBy, each group headed by its
+// label and followed by a subtotal row of its process count and average wait and turnaround.
+func outputGroupedSchedule(w io.Writer, result SchedulerResult, opts Options) {
+	scale := float64(opts.ticksPerUnit())
+	rows := result.rows(opts.TimeScale)
+	grouped := make([][]string, 0, len(rows))
+	for _, g := range scheduleGroups(result, opts.GroupBy, opts) {
+		grouped = append(grouped, []string{groupLabel(opts.GroupBy, g.Key), "", "", "", "", "", ""})
+		var wait, turnaround float64
+		for _, i := range g.Members {
+			grouped = append(grouped, rows[i])
+			wait += float64(result.Stats[i].Wait)
+			turnaround += float64(result.Stats[i].Turnaround)
+		}
+		n := float64(len(g.Members)) * scale
+		grouped = append(grouped, []string{fmt.Sprintf("Subtotal (%v)", len(g.Members)), "", "", "",
+			formatFloat(wait/n, opts.Precision), formatFloat(turnaround/n, opts.Precision), ""})
+	}
+
+	wait, turnaround, throughput := result.averages(opts.TimeScale)
+	outputSchedule(w, grouped, wait, turnaround, throughput, opts)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_outputGroupedSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 1, BurstDuration: 2, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 4, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 2, Priority: 2},
+	}
+	opts := DefaultOptions()
+	opts.GroupBy, opts.Plain = GroupByPriority, true
+	result := FCFSSchedule("FCFS", processes, opts)
+
+	groups := scheduleGroups(result, opts.GroupBy, opts)
+	want := []ScheduleGroup{{Key: 1, Members: []int{1}}, {Key: 2, Members: []int{0, 2}}}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("scheduleGroups() = %+v, want %+v", groups, want)
+	}
+
+	var w bytes.Buffer
+	outputGroupedSchedule(&w, result, opts)
+	got := w.String()
+	// Process 2 waits 2 behind process 1. Process 1 doesn't wait and process 3 waits 5, averaging 2.5.
+	for _, row := range []string{"Priority 1", "Subtotal (1)", "2.00", "Priority 2", "Subtotal (2)", "2.50"} {
+		if !strings.Contains(got, row) {
+			t.Errorf("outputGroupedSchedule() = \n%v\nmissing %q", got, row)
+		}
+	}
+	if strings.Index(got, "Priority 1") > strings.Index(got, "Priority 2") {
+		t.Errorf("outputGroupedSchedule() = \n%v\nwant the highest priority first", got)
+	}
+}
+
+func Test_completionCore(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2, Core: 0},
+		{PID: 2, Start: 0, Stop: 3, Core: 1},
+		{PID: 1, Start: 3, Stop: 4, Core: 1},
+	}
+	if got, want := completionCore(gantt), map[int64]int{1: 1, 2: 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("completionCore() = %v, want %v", got, want)
+	}
+}
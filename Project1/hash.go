@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sort"
+)
+
+// hash fingerprints the result, its gantts and the metrics of every process, as 16 hex digits. The slices and
+// stats are written in a canonical order, so results that schedule the same way hash the same, however they're
+// ordered for output.
+func (r SchedulerResult) hash() string {
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "%q\n", r.Title)
+	for _, gantt := range [][]TimeSlice{r.Gantt, r.IOGantt} {
+		slices := append([]TimeSlice(nil), gantt...)
+		sort.SliceStable(slices, func(a, b int) bool {
+			if slices[a].Core != slices[b].Core {
+				return slices[a].Core < slices[b].Core
+			}
+			return slices[a].Start < slices[b].Start
+		})
+		for _, s := range slices {
+			_, _ = fmt.Fprintf(h, "%v %v %v %v\n", s.PID, s.Core, s.Start, s.Stop)
+		}
+		_, _ = fmt.Fprintln(h)
+	}
+	stats := append([]ProcessStats(nil), r.Stats...)
+	sort.SliceStable(stats, func(a, b int) bool { return stats[a].ProcessID < stats[b].ProcessID })
+	for _, s := range stats {
+		_, _ = fmt.Fprintf(h, "%v %v %v %v\n", s.ProcessID, s.Wait, s.Turnaround, s.Completion)
+	}
+	_, _ = fmt.Fprintf(h, "%v %v %v %v %v\n", r.AveWait, r.AveTurnaround, r.Throughput, r.Unfinished, r.Aborted)
+
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// outputHash writes the result's hash, for -repeatable-hash.
+func outputHash(w io.Writer, result SchedulerResult) {
+	_, _ = fmt.Fprintf(w, "Result hash: %v\n\n", result.hash())
+}
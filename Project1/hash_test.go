@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSchedulerResult_hash(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1},
+	}
+	changed := append([]Process(nil), processes...)
+	changed[1].BurstDuration = 4
+	hash := func(processes []Process) string {
+		return RRSchedule("Round-robin", processes, DefaultOptions()).hash()
+	}
+
+	got := hash(processes)
+	if len(got) != 16 {
+		t.Errorf("hash() = %q, want 16 hex digits", got)
+	}
+	if again := hash(processes); again != got {
+		t.Errorf("hash() = %v then %v, want identical runs to hash the same", got, again)
+	}
+	if other := hash(changed); other == got {
+		t.Errorf("hash() = %v for both, want a different burst to change it", got)
+	}
+	result := RRSchedule("Round-robin", processes, DefaultOptions())
+	if reordered := result.inInputOrder().hash(); reordered != got {
+		t.Errorf("hash() = %v in input order, want %v", reordered, got)
+	}
+}
+
+func Test_outputResult_repeatableHash(t *testing.T) {
+	t.Parallel()
+	result := FCFSSchedule("First-come, first-serve", []Process{{ProcessID: 1, BurstDuration: 2}}, DefaultOptions())
+	opts := DefaultOptions()
+	opts.RepeatableHash = true
+	for _, summaryOnly := range []bool{false, true} {
+		opts.SummaryOnly = summaryOnly
+		var w bytes.Buffer
+		outputResult(&w, result, opts)
+		if !strings.Contains(w.String(), result.hash()) {
+			t.Errorf("outputResult() with SummaryOnly %v missing the hash %v: %v", summaryOnly, result.hash(), w.String())
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// defaultBurstBucket is the width of the burst histogram's buckets in units of time.
+const defaultBurstBucket = 5
+
+// maxHistogramBar is the longest bar of the burst histogram, longer ones being scaled down to it.
+const maxHistogramBar = 40
+
+// BurstBucket counts the processes with a burst from From up to but not including To.
+type BurstBucket struct {
+	From, To int64
+	Count    int
+}
+
+// burstHistogram buckets the processes' bursts into buckets of the width, from the shortest burst's bucket to
+// the longest's, the empty buckets between included.
+func burstHistogram(processes []Process, width int64) []BurstBucket {
+	if len(processes) == 0 || width <= 0 {
+		return nil
+	}
+	shortest, longest := processes[0].BurstDuration, processes[0].BurstDuration
+	for _, p := range processes {
+		if p.BurstDuration < shortest {
+			shortest = p.BurstDuration
+		}
+		if p.BurstDuration > longest {
+			longest = p.BurstDuration
+		}
+	}
+
+	first := bucketOf(shortest, width)
+	buckets := make([]BurstBucket, bucketOf(longest, width)-first+1)
+	for i := range buckets {
+		buckets[i].From = (first + int64(i)) * width
+		buckets[i].To = buckets[i].From + width
+	}
+	for _, p := range processes {
+		buckets[bucketOf(p.BurstDuration, width)-first].Count++
+	}
+
+	return buckets
+}
+
+// bucketOf is the number of the bucket of the width a burst falls in, rounding down so a negative burst of
+// a malformed workload falls in a bucket below zero.
+func bucketOf(burst, width int64) int64 {
+	if burst < 0 {
+		return -((-burst + width - 1) / width)
+	}
+
+	return burst / width
+}
+
+// outputBurstHistogram draws the histogram as a bar of #s per bucket, e.g. "[0, 5)  ### 3".
+func outputBurstHistogram(w io.Writer, buckets []BurstBucket, opts Options) {
+	most := 0
+	ranges := make([]string, len(buckets))
+	widest := 0
+	for i, b := range buckets {
+		if b.Count > most {
+			most = b.Count
+		}
+		ranges[i] = fmt.Sprintf("[%v, %v)", formatTime(b.From, opts.TimeScale), formatTime(b.To, opts.TimeScale))
+		if len(ranges[i]) > widest {
+			widest = len(ranges[i])
+		}
+	}
+
+	_, _ = fmt.Fprintln(w, "Burst histogram")
+	for i, b := range buckets {
+		bar := b.Count
+		if most > maxHistogramBar {
+			bar = b.Count * maxHistogramBar / most
+		}
+		_, _ = fmt.Fprintf(w, "%-*v  %v %v\n", widest, ranges[i], repeat("#", bar), b.Count)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func Test_burstHistogram(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 6},
+		{ProcessID: 2, BurstDuration: 9},
+		{ProcessID: 3, BurstDuration: 5},
+		{ProcessID: 4, BurstDuration: 17},
+		{ProcessID: 5, BurstDuration: 7},
+	}
+	want := []BurstBucket{
+		{From: 5, To: 10, Count: 4},
+		{From: 10, To: 15, Count: 0},
+		{From: 15, To: 20, Count: 1},
+	}
+	if got := burstHistogram(processes, 5); !reflect.DeepEqual(got, want) {
+		t.Errorf("burstHistogram() = %+v, want %+v", got, want)
+	}
+
+	negative := []Process{{ProcessID: 1, BurstDuration: -5}, {ProcessID: 2, BurstDuration: -1}}
+	want = []BurstBucket{{From: -5, To: 0, Count: 2}}
+	if got := burstHistogram(negative, 5); !reflect.DeepEqual(got, want) {
+		t.Errorf("burstHistogram() of negative bursts = %+v, want %+v", got, want)
+	}
+}
+
+func Test_outputBurstHistogram(t *testing.T) {
+	t.Parallel()
+	buckets := []BurstBucket{
+		{From: 0, To: 5, Count: 3},
+		{From: 5, To: 10, Count: 0},
+		{From: 10, To: 15, Count: 1},
+	}
+	var w bytes.Buffer
+	outputBurstHistogram(&w, buckets, DefaultOptions())
+	want := "Burst histogram\n" +
+		"[0, 5)    ### 3\n" +
+		"[5, 10)    0\n" +
+		"[10, 15)  # 1\n"
+	if w.String() != want {
+		t.Errorf("outputBurstHistogram() = %q, want %q", w.String(), want)
+	}
+}
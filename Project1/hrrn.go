@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// ResponseRatio is a process's response ratio when HRRNSchedule dispatched it.
+type ResponseRatio struct {
+	Time  int64
+	PID   int64
+	Ratio float64
+}
+
+// responseRatio is how long the ready process has waited plus the CPU time it has left, over the CPU time it has
+// left, so 1 for a process that hasn't waited at all. Waiting excludes the time it has run and done I/O.
+func responseRatio(r *runnable, time int64) float64 {
+	waited := time - r.ArrivalTime - (r.BurstDuration - r.Remaining)
+	for _, req := range r.IO[:r.NextIO] {
+		waited -= req.Duration
+	}
+
+	return float64(waited+r.Remaining) / float64(r.Remaining)
+}
+
+// HRRNSchedule (highest response ratio next) runs the ready process with the highest responseRatio to completion
+// whenever the CPU frees up. Short processes are favored like SJF, but a long process's ratio grows the longer it
+// waits until it's chosen, so it can't starve. Ties go by opts.TieBreak. The ratio of each process dispatched is
+// returned in the result's ResponseRatios.
+func HRRNSchedule(title string, inputProcesses []Process, opts Options) SchedulerResult {
+	ratios := make([]ResponseRatio, 0)
+	gantt, ioGantt, err := preemptiveSchedule(inputProcesses, 0, opts, nonPreemptive(func(ready []*runnable, time int64) int {
+		best, bestRatio := 0, responseRatio(ready[0], time)
+		for i := 1; i < len(ready); i++ {
+			ratio := responseRatio(ready[i], time)
+			if ratio > bestRatio || (ratio == bestRatio && opts.before(ready[i].Process, ready[best].Process, false)) {
+				best, bestRatio = i, ratio
+			}
+		}
+		ratios = append(ratios, ResponseRatio{Time: time, PID: ready[best].ProcessID, Ratio: bestRatio})
+		return best
+	}))
+	result := calculateCompletionStats(title, inputProcesses, gantt)
+	result.IOGantt = ioGantt
+	result.ResponseRatios = ratios
+	result.Err = err
+
+	return result
+}
+
+// explainResponseRatio explains HRRNSchedule's choices, whose ratios are reported after its schedule.
+func explainResponseRatio(candidate, []candidate, Options) string {
+	return "highest response ratio, waiting plus remaining burst over remaining burst"
+}
+
+// responseRatioSummary is the average of the response ratios and the highest of them, the first if several tie.
+func responseRatioSummary(ratios []ResponseRatio) (float64, ResponseRatio) {
+	if len(ratios) == 0 {
+		return 0, ResponseRatio{}
+	}
+	var total float64
+	highest := ratios[0]
+	for _, r := range ratios {
+		total += r.Ratio
+		if r.Ratio > highest.Ratio {
+			highest = r
+		}
+	}
+
+	return total / float64(len(ratios)), highest
+}
+
+func outputResponseRatios(w io.Writer, ratios []ResponseRatio, opts Options) {
+	average, highest := responseRatioSummary(ratios)
+	_, _ = fmt.Fprintf(w, "Response ratio at dispatch: average %v, maximum %v (process %v at %v)\n\n",
+		formatFloat(average, opts.Precision), formatFloat(highest.Ratio, opts.Precision),
+		highest.PID, formatTime(highest.Time, opts.TimeScale))
+}
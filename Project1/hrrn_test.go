@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHRRNSchedule(t *testing.T) {
+	t.Parallel()
+	// Process 2 waits out all of process 1, so by 12 its ratio is (11+4)/4, beating the just arrived process 3.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 12},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 4},
+		{ProcessID: 3, ArrivalTime: 11, BurstDuration: 2},
+	}
+	result := HRRNSchedule("HRRN", processes, DefaultOptions())
+	wantGantt := []TimeSlice{{PID: 1, Start: 0, Stop: 12}, {PID: 2, Start: 12, Stop: 16}, {PID: 3, Start: 16, Stop: 18}}
+	if !reflect.DeepEqual(result.Gantt, wantGantt) {
+		t.Errorf("HRRNSchedule() gantt = %v, want %v", result.Gantt, wantGantt)
+	}
+	wantRatios := []ResponseRatio{{Time: 0, PID: 1, Ratio: 1}, {Time: 12, PID: 2, Ratio: 3.75}, {Time: 16, PID: 3, Ratio: 3.5}}
+	if !reflect.DeepEqual(result.ResponseRatios, wantRatios) {
+		t.Errorf("HRRNSchedule() ratios = %v, want %v", result.ResponseRatios, wantRatios)
+	}
+
+	average, highest := responseRatioSummary(result.ResponseRatios)
+	if average != 2.75 || highest != wantRatios[1] {
+		t.Errorf("responseRatioSummary() = %v, %+v, want 2.75 and process 2's ratio", average, highest)
+	}
+	if m := metricsRecord(result, DefaultOptions()); m.MaxResponseRatio != 3.75 || m.AverageResponseRatio != 2.75 {
+		t.Errorf("metricsRecord() response ratios = %v, %v, want 2.75, 3.75", m.AverageResponseRatio, m.MaxResponseRatio)
+	}
+
+	var w bytes.Buffer
+	outputResponseRatios(&w, result.ResponseRatios, DefaultOptions())
+	if want := "average 2.75, maximum 3.75 (process 2 at 12)"; !strings.Contains(w.String(), want) {
+		t.Errorf("outputResponseRatios() = %q, want %q", w.String(), want)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// idleSlices are the gaps in a single core's gantt between time zero and its makespan, when no process ran.
+func idleSlices(gantt []TimeSlice) []TimeSlice {
+	sorted := make([]TimeSlice, len(gantt))
+	copy(sorted, gantt)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+
+	idle := make([]TimeSlice, 0)
+	var busyUntil int64
+	for _, s := range sorted {
+		if s.Start > busyUntil {
+			idle = append(idle, TimeSlice{Start: busyUntil, Stop: s.Start})
+		}
+		if s.Stop > busyUntil {
+			busyUntil = s.Stop
+		}
+	}
+
+	return idle
+}
+
+// splitIdle splits the idle slices wherever one of the events, the other slices of the schedule, starts or stops
+// during them, so a gap shows when something happened elsewhere. With opts.GanttMergeIdle, the default, they're
+// left whole.
+func splitIdle(idle, events []TimeSlice, opts Options) []TimeSlice {
+	if opts.GanttMergeIdle {
+		return idle
+	}
+	split := make([]TimeSlice, 0, len(idle))
+	for _, s := range idle {
+		boundaries := make([]int64, 0)
+		for _, e := range events {
+			for _, t := range []int64{e.Start, e.Stop} {
+				if t > s.Start && t < s.Stop {
+					boundaries = append(boundaries, t)
+				}
+			}
+		}
+		sort.Slice(boundaries, func(i, j int) bool { return boundaries[i] < boundaries[j] })
+		for _, t := range boundaries {
+			if t > s.Start {
+				split = append(split, TimeSlice{Start: s.Start, Stop: t})
+				s.Start = t
+			}
+		}
+		split = append(split, s)
+	}
+
+	return split
+}
+
+// idleTime totals the idle slices, returning it with its percentage of the makespan.
+func idleTime(gantt []TimeSlice) (int64, float64) {
+	var total int64
+	for _, s := range idleSlices(gantt) {
+		total += s.Stop - s.Start
+	}
+	makespan := ganttMakespan(gantt)
+	if makespan == 0 {
+		return 0, 0
+	}
+
+	return total, 100 * float64(total) / float64(makespan)
+}
+
+// outputIdle lists when the CPU sat idle with the idle time accumulated so far, skipping a CPU that never did.
+func outputIdle(w io.Writer, gantt []TimeSlice, opts Options) {
+	idle := idleSlices(gantt)
+	if len(idle) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "CPU idle")
+	table := newTable(w, opts)
+	table.SetHeader([]string{"Start", "Stop", "Idle", "Accumulated"})
+	var accumulated int64
+	for _, s := range idle {
+		accumulated += s.Stop - s.Start
+		table.Append([]string{
+			formatTime(s.Start, opts.TimeScale),
+			formatTime(s.Stop, opts.TimeScale),
+			formatTime(s.Stop-s.Start, opts.TimeScale),
+			formatTime(accumulated, opts.TimeScale),
+		})
+	}
+	total, percent := idleTime(gantt)
+	table.SetFooter([]string{"", "", "Total",
+		formatTime(total, opts.TimeScale) + " (" + formatFloat(percent, opts.Precision) + "%)"})
+	table.Render()
+}
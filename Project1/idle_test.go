@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_idleTime(t *testing.T) {
+	t.Parallel()
+	// The CPU waits for process 1 to arrive, then sits idle between process 1 finishing and process 2 arriving.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 2, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 9, BurstDuration: 1},
+	}
+	gantt := FCFSIOSchedule("FCFS", processes, DefaultOptions()).Gantt
+	if want := []TimeSlice{{Start: 0, Stop: 2}, {Start: 5, Stop: 9}}; !reflect.DeepEqual(idleSlices(gantt), want) {
+		t.Errorf("idleSlices() = %v, want %v", idleSlices(gantt), want)
+	}
+	total, percent := idleTime(gantt)
+	if total != 6 || percent != 60 {
+		t.Errorf("idleTime() = %v, %v, want 6, 60", total, percent)
+	}
+
+	var w bytes.Buffer
+	outputIdle(&w, gantt, DefaultOptions())
+	if !strings.Contains(w.String(), "6 (60.00%)") {
+		t.Errorf("outputIdle() should report the total idle time, got:\n%v", w.String())
+	}
+}
+
+func Test_outputIdle_busy(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	outputIdle(&w, []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 3}}, DefaultOptions())
+	if w.Len() != 0 {
+		t.Errorf("outputIdle() of a CPU that was never idle = %q, want nothing", w.String())
+	}
+}
+
+func Test_outputGanttEvents_mergeIdle(t *testing.T) {
+	t.Parallel()
+	// Core 1 is idle twice, while core 0 switches processes at 3 and at 7.
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 3, Core: 0},
+		{PID: 2, Start: 3, Stop: 7, Core: 0},
+		{PID: 6, Start: 7, Stop: 9, Core: 0},
+		{PID: 3, Start: 0, Stop: 1, Core: 1},
+		{PID: 4, Start: 5, Stop: 6, Core: 1},
+		{PID: 5, Start: 8, Stop: 9, Core: 1},
+	}
+	tests := []struct {
+		name  string
+		merge bool
+		want  string
+	}{
+		{
+			name:  "merged",
+			merge: true,
+			want:  "|   3   |  idle  |   4   |  idle  |   5   |\n0\t1\t5\t6\t8\t9\n",
+		},
+		{
+			name: "split",
+			want: "|   3   |  idle  |  idle  |   4   |  idle  |  idle  |   5   |\n0\t1\t3\t5\t6\t7\t8\t9\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opts := DefaultOptions()
+			opts.GanttMergeIdle = tt.merge
+			var w bytes.Buffer
+			outputGanttEvents(&w, "Gantt schedule (core 1)", coreGantt(gantt, 1), gantt, opts)
+			if !strings.Contains(w.String(), tt.want) {
+				t.Errorf("outputGanttEvents() %v = %q, want %q", tt.name, w.String(), tt.want)
+			}
+		})
+	}
+}
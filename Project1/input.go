@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Input formats of the scheduling file.
+const (
+	// InputAuto reads a file ending .json as JSON and anything else, stdin included, as CSV.
+	InputAuto = "auto"
+	InputCSV  = "csv"
+	InputJSON = "json"
+)
+
+// inputFormats are the valid -input-format values.
+var inputFormats = []string{InputAuto, InputCSV, InputJSON}
+
+// stdinName is the scheduling file name that reads the processes from stdin.
+const stdinName = "-"
+
+// inputFormat is the format to read the named scheduling file in, the -input-format unless that's auto.
+func (o Options) inputFormat(name string) string {
+	if o.InputFormat != "" && o.InputFormat != InputAuto {
+		return o.InputFormat
+	}
+	if name != stdinName && strings.EqualFold(filepath.Ext(name), ".json") {
+		return InputJSON
+	}
+
+	return InputCSV
+}
+
+// loadInput reads processes from r in the input format, returning them with their time scale and how many
+// malformed processes were skipped. With a nil skipped the first malformed process is an error,
+// otherwise they're skipped and why is written to skipped, like loadProcessesBestEffort.
+// CSV columns are read in the columns' order, the default order if they're nil; JSON members are named.
+func loadInput(r io.Reader, format string, columns []string, skipped io.Writer) ([]Process, int64, int, error) {
+	if format == InputJSON {
+		return readJSONProcesses(r, skipped)
+	}
+
+	return readProcesses(r, columns, skipped)
+}
+
+// limitProcesses are the first limit processes, or all of them if the limit is zero or there are no more.
+func limitProcesses(processes []Process, limit int) []Process {
+	if limit <= 0 || limit >= len(processes) {
+		return processes
+	}
+
+	return processes[:limit]
+}
+
+// jsonProcess is a process in a JSON scheduling file, an array of them. Its members are the CSV columns,
+// with the I/O requests in the same "at:duration" form, and only id, burst and arrival are required.
+type jsonProcess struct {
+	ID        json.Number `json:"id"`
+	Burst     json.Number `json:"burst"`
+	Arrival   json.Number `json:"arrival"`
+	Priority  json.Number `json:"priority"`
+	Affinity  json.Number `json:"affinity"`
+	Deadline  json.Number `json:"deadline"`
+	IO        string      `json:"io"`
+	Period    json.Number `json:"period"`
+	Weight    json.Number `json:"weight"`
+	Batch     json.Number `json:"batch"`
+	Instances json.Number `json:"instances"`
+	Nice      json.Number `json:"nice,omitempty"`
+	Value     json.Number `json:"value"`
+}
+
+// fields are the process as a CSV row, its missing optional members zero, except a missing nice, which is empty.
+func (p jsonProcess) fields() []string {
+	optional := func(n json.Number) string {
+		if n == "" {
+			return "0"
+		}
+		return n.String()
+	}
+
+	return []string{
+		p.ID.String(), p.Burst.String(), p.Arrival.String(),
+		optional(p.Priority), optional(p.Affinity), optional(p.Deadline), p.IO, optional(p.Period), optional(p.Weight),
+		optional(p.Batch), optional(p.Instances), p.Nice.String(), optional(p.Value),
+	}
+}
+
+// readJSONProcesses is readProcesses for a JSON scheduling file, numbering the processes by their position
+// in the array.
+func readJSONProcesses(r io.Reader, skipped io.Writer) ([]Process, int64, int, error) {
+	dec := json.NewDecoder(skipBOM(r))
+	dec.DisallowUnknownFields()
+	var records []jsonProcess
+	if err := dec.Decode(&records); err != nil {
+		return nil, 0, 0, fmt.Errorf("%w: reading JSON", err)
+	}
+
+	rows := make([][]string, len(records))
+	for i := range records {
+		rows[i] = records[i].fields()
+	}
+
+	return parseRows(rows, "process", skipped)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOptions_inputFormat(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		format string
+		file   string
+		want   string
+	}{
+		{name: "auto csv", format: InputAuto, file: "processes.csv", want: InputCSV},
+		{name: "auto json", format: InputAuto, file: "processes.JSON", want: InputJSON},
+		{name: "auto stdin", format: InputAuto, file: stdinName, want: InputCSV},
+		{name: "forced json", format: InputJSON, file: "processes.txt", want: InputJSON},
+		{name: "forced csv", format: InputCSV, file: "processes.json", want: InputCSV},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opts := DefaultOptions()
+			opts.InputFormat = tt.format
+			if got := opts.inputFormat(tt.file); got != tt.want {
+				t.Errorf("inputFormat(%q) = %v, want %v", tt.file, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_loadInput(t *testing.T) {
+	t.Parallel()
+	want := []Process{
+		{ProcessID: 1, BurstDuration: 15, ArrivalTime: 5, Priority: 2, Line: 1},
+		{ProcessID: 2, BurstDuration: 3, ArrivalTime: 0, Line: 2,
+			IO: []IORequest{{At: 1, Duration: 4}}, Weight: 3},
+	}
+
+	t.Run("json in a .txt file", func(t *testing.T) {
+		t.Parallel()
+		name := filepath.Join(t.TempDir(), "processes.txt")
+		data := `[{"id": 1, "burst": 15, "arrival": 5, "priority": 2}, {"id": 2, "burst": 3, "arrival": 0, "io": "1:4", "weight": 3}]`
+		if err := os.WriteFile(name, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		f, closeFile, err := openProcessingFile("binary_name", name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer closeFile()
+		opts := DefaultOptions()
+		opts.InputFormat = InputJSON
+		got, scale, _, err := loadInput(f, opts.inputFormat(name), nil, nil)
+		if err != nil {
+			t.Fatalf("loadInput() error = %v", err)
+		}
+		if scale != 1 || !reflect.DeepEqual(got, want) {
+			t.Errorf("loadInput() = %+v at scale %v, want %+v at scale 1", got, scale, want)
+		}
+	})
+
+	t.Run("csv on stdin", func(t *testing.T) {
+		t.Parallel()
+		stdin := strings.NewReader("1,15,5,2,0,0,,0,0\n2,3,0,0,0,0,1:4,0,3\n")
+		got, scale, _, err := loadInput(stdin, DefaultOptions().inputFormat(stdinName), nil, nil)
+		if err != nil {
+			t.Fatalf("loadInput() error = %v", err)
+		}
+		if scale != 1 || !reflect.DeepEqual(got, want) {
+			t.Errorf("loadInput() = %+v at scale %v, want %+v at scale 1", got, scale, want)
+		}
+	})
+
+	t.Run("json with an unknown member", func(t *testing.T) {
+		t.Parallel()
+		_, _, _, err := loadInput(strings.NewReader(`[{"id": 1, "burst": 1, "arrival": 0, "prio": 2}]`), InputJSON, nil, nil)
+		if err == nil {
+			t.Errorf("loadInput() error = nil, want the unknown member rejected")
+		}
+	})
+}
+
+func Test_limitProcesses(t *testing.T) {
+	t.Parallel()
+	var b strings.Builder
+	for i := 1; i <= 100; i++ {
+		_, _ = fmt.Fprintf(&b, "%v,%v,%v\n", i, i%7+1, i)
+	}
+	processes, _, _, err := loadInput(strings.NewReader(b.String()), InputCSV, nil, nil)
+	if err != nil {
+		t.Fatalf("loadInput() error = %v", err)
+	}
+
+	limited := limitProcesses(processes, 5)
+	stats := FCFSSchedule("FCFS", limited, DefaultOptions()).Stats
+	if len(stats) != 5 || stats[0].ProcessID != 1 || stats[4].ProcessID != 5 {
+		t.Errorf("limitProcesses() scheduled %+v, want the first 5 processes", stats)
+	}
+	if got := limitProcesses(processes, 0); len(got) != 100 {
+		t.Errorf("limitProcesses() with no limit kept %v processes, want all 100", len(got))
+	}
+	if got := limitProcesses(processes[:3], 5); len(got) != 3 {
+		t.Errorf("limitProcesses() beyond the end kept %v processes, want 3", len(got))
+	}
+}
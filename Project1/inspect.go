@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// QueuedProcess is a process in the ready queue, or on the CPU, with the CPU time it still needs.
+type QueuedProcess struct {
+	PID       int64
+	Remaining int64
+}
+
+// Inspection is what a simulation was doing at a moment, once everything happening at that instant had.
+type Inspection struct {
+	Time int64
+	// Running is the process on the CPU, nil if it was idle.
+	Running *QueuedProcess
+	// Ready are the processes waiting in the ready queue, in its order.
+	Ready []QueuedProcess
+}
+
+// inspectable are the schedulers that can be inspected with -inspect-at.
+var inspectable = map[string]engineScheduler{
+	"fcfs-io": resumable["fcfs-io"],
+	"edf":     resumable["edf"],
+	"sjf":     resumable["sjf"],
+}
+
+// inspectableNames lists the schedulers that can be inspected, sorted.
+func inspectableNames() []string {
+	names := make([]string, 0, len(inspectable))
+	for name := range inspectable {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// inspectSchedule simulates the processes with the scheduler, recording what was running and waiting at each
+// of the times, in order of time.
+func inspectSchedule(algorithm string, processes []Process, times []int64, opts Options) ([]Inspection, error) {
+	scheduler, ok := inspectable[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q can't be inspected, only %v", ErrInvalidArgs,
+			algorithm, strings.Join(inspectableNames(), ", "))
+	}
+	if scheduler.cpuBound {
+		processes = cpuBound(processes)
+	}
+	e := newEngine(processes, opts)
+	quantum, pick := scheduler.pick(opts, e)
+	e.inspectAt = append([]int64(nil), times...)
+	sort.Slice(e.inspectAt, func(i, j int) bool { return e.inspectAt[i] < e.inspectAt[j] })
+	if err := e.run(quantum, pick, opts.until()); err != nil {
+		return nil, err
+	}
+
+	return e.inspections, nil
+}
+
+// outputInspections writes what was running and in the ready queue at each inspection, with the time they had left.
+func outputInspections(w io.Writer, inspections []Inspection, opts Options) {
+	format := func(q QueuedProcess) string {
+		return fmt.Sprintf("%v (%v left)", q.PID, formatTime(q.Remaining, opts.TimeScale))
+	}
+	for _, in := range inspections {
+		running := "idle"
+		if in.Running != nil {
+			running = format(*in.Running)
+		}
+		ready := make([]string, len(in.Ready))
+		for i, q := range in.Ready {
+			ready[i] = format(q)
+		}
+		_, _ = fmt.Fprintf(w, "Time %v\n", formatTime(in.Time, opts.TimeScale))
+		_, _ = fmt.Fprintf(w, "Running: %v\n", running)
+		_, _ = fmt.Fprintf(w, "Ready:   [%v]\n\n", strings.Join(ready, ", "))
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_inspectSchedule(t *testing.T) {
+	t.Parallel()
+	// Process 2 preempts process 1 at 2, and process 3 arrives at 4 to wait behind process 2.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6},
+		{ProcessID: 2, ArrivalTime: 2, BurstDuration: 3},
+		{ProcessID: 3, ArrivalTime: 4, BurstDuration: 2},
+		{ProcessID: 4, ArrivalTime: 20, BurstDuration: 1},
+	}
+	got, err := inspectSchedule("sjf", processes, []int64{4, 1, 9, 15, 30}, DefaultOptions())
+	if err != nil {
+		t.Fatalf("inspectSchedule() error = %v", err)
+	}
+	want := []Inspection{
+		{Time: 1, Running: &QueuedProcess{PID: 1, Remaining: 5}, Ready: []QueuedProcess{}},
+		{Time: 4, Running: &QueuedProcess{PID: 2, Remaining: 1}, Ready: []QueuedProcess{{PID: 1, Remaining: 4}, {PID: 3, Remaining: 2}}},
+		{Time: 9, Running: &QueuedProcess{PID: 1, Remaining: 2}, Ready: []QueuedProcess{}},
+		{Time: 15, Ready: []QueuedProcess{}},
+		{Time: 30, Ready: []QueuedProcess{}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("inspectSchedule() = %+v, want %+v", got, want)
+	}
+
+	if _, err := inspectSchedule("rr", processes, []int64{1}, DefaultOptions()); err == nil {
+		t.Errorf("inspectSchedule() error = nil, want rr rejected")
+	}
+
+	var w bytes.Buffer
+	outputInspections(&w, got[1:2], DefaultOptions())
+	if want := "Time 4\nRunning: 2 (1 left)\nReady:   [1 (4 left), 3 (2 left)]\n"; !strings.Contains(w.String(), want) {
+		t.Errorf("outputInspections() = %q, want %q", w.String(), want)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// CriticalSection is a stretch of a process's burst it must hold the shared lock for.
+type CriticalSection struct {
+	// At is how much CPU time the process has used when it needs the lock.
+	At int64
+	// Duration is how much CPU time it holds the lock for.
+	Duration int64
+}
+
+// inversionScenario is the classic priority inversion: a low priority process takes the lock, the high priority
+// process that needs it next blocks, and a medium priority process that doesn't need it keeps the holder off the CPU.
+var inversionScenario = struct {
+	processes []Process
+	sections  map[int64]CriticalSection
+}{
+	processes: []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 3},
+		{ProcessID: 2, ArrivalTime: 2, BurstDuration: 3, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 3, BurstDuration: 6, Priority: 2},
+	},
+	sections: map[int64]CriticalSection{
+		1: {At: 1, Duration: 3},
+		2: {At: 1, Duration: 1},
+	},
+}
+
+// lockSchedule runs preemptive priority scheduling, where a lower number is a higher priority, with a single lock
+// the processes' critical sections need. A process needing the lock while another holds it blocks until it's released.
+// With inherit the holder runs at the highest priority of the processes blocked on it. It returns the gantt and
+// how long each process spent blocked on the lock.
+func lockSchedule(inputProcesses []Process, sections map[int64]CriticalSection, inherit bool) ([]TimeSlice, map[int64]int64) {
+	processes := make([]Process, len(inputProcesses))
+	copy(processes, inputProcesses)
+	sort.SliceStable(processes, func(a, b int) bool { return processes[a].ArrivalTime < processes[b].ArrivalTime })
+
+	var (
+		time         int64
+		holder       int64 = -1
+		ran                = make(map[int64]int64, len(processes))
+		blockedSince       = make(map[int64]int64)
+		blocking           = make(map[int64]int64, len(processes))
+		gantt              = make([]TimeSlice, 0)
+		done         int
+	)
+	priority := func(p Process) int64 {
+		if !inherit || p.ProcessID != holder {
+			return p.Priority
+		}
+		effective := p.Priority
+		for _, q := range processes {
+			if _, ok := blockedSince[q.ProcessID]; ok && q.Priority < effective {
+				effective = q.Priority
+			}
+		}
+		return effective
+	}
+
+	for done < len(processes) {
+		var running int
+		for {
+			running = -1
+			for i, p := range processes {
+				_, blocked := blockedSince[p.ProcessID]
+				if p.ArrivalTime > time || ran[p.ProcessID] >= p.BurstDuration || blocked {
+					continue
+				}
+				if running < 0 || priority(p) < priority(processes[running]) {
+					running = i
+				}
+			}
+			if running < 0 {
+				break
+			}
+			p := processes[running]
+			if s, ok := sections[p.ProcessID]; ok && ran[p.ProcessID] == s.At && holder != p.ProcessID {
+				if holder >= 0 {
+					blockedSince[p.ProcessID] = time
+					continue
+				}
+				holder = p.ProcessID
+			}
+			break
+		}
+		if running < 0 {
+			time++
+			continue
+		}
+
+		p := processes[running]
+		if n := len(gantt); n > 0 && gantt[n-1].PID == p.ProcessID && gantt[n-1].Stop == time {
+			gantt[n-1].Stop++
+		} else {
+			gantt = append(gantt, TimeSlice{PID: p.ProcessID, Start: time, Stop: time + 1})
+		}
+		time++
+		ran[p.ProcessID]++
+		if s, ok := sections[p.ProcessID]; ok && holder == p.ProcessID && ran[p.ProcessID] == s.At+s.Duration {
+			holder = -1
+			for pid, since := range blockedSince {
+				blocking[pid] += time - since
+				delete(blockedSince, pid)
+			}
+		}
+		if ran[p.ProcessID] == p.BurstDuration {
+			done++
+		}
+	}
+
+	return gantt, blocking
+}
+
+// outputInversionDemo runs the priority inversion scenario with and without priority inheritance,
+// showing each gantt and how long the high priority process was blocked.
+func outputInversionDemo(w io.Writer, opts Options) {
+	high := inversionScenario.processes[1].ProcessID
+	for _, inherit := range []bool{false, true} {
+		title := "Priority inversion"
+		if inherit {
+			title = "Priority inheritance"
+		}
+		outputTitle(w, title)
+		gantt, blocking := lockSchedule(inversionScenario.processes, inversionScenario.sections, inherit)
+		outputGantt(w, "Gantt schedule", gantt, opts)
+		_, _ = fmt.Fprintf(w, "High priority process %v was blocked for %v\n\n", high, blocking[high])
+	}
+}
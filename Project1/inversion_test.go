@@ -0,0 +1,54 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_lockSchedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		inherit      bool
+		wantGantt    []TimeSlice
+		wantBlocking int64
+	}{
+		{
+			name:    "inversion",
+			inherit: false,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 2, Stop: 3},
+				{PID: 3, Start: 3, Stop: 9},
+				{PID: 1, Start: 9, Stop: 11},
+				{PID: 2, Start: 11, Stop: 13},
+			},
+			wantBlocking: 8,
+		},
+		{
+			name:    "inheritance",
+			inherit: true,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 2, Stop: 3},
+				{PID: 1, Start: 3, Stop: 5},
+				{PID: 2, Start: 5, Stop: 7},
+				{PID: 3, Start: 7, Stop: 13},
+			},
+			wantBlocking: 2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			gantt, blocking := lockSchedule(inversionScenario.processes, inversionScenario.sections, tt.inherit)
+			if !reflect.DeepEqual(gantt, tt.wantGantt) {
+				t.Errorf("lockSchedule() gantt = %v, want %v", gantt, tt.wantGantt)
+			}
+			if blocking[2] != tt.wantBlocking {
+				t.Errorf("lockSchedule() high priority blocking = %v, want %v", blocking[2], tt.wantBlocking)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// IORequest blocks a process on the I/O device for Duration once it has had At units of CPU time.
+type IORequest struct {
+	At       int64
+	Duration int64
+}
+
+// ioTime is the total time the process spends doing I/O.
+func (p Process) ioTime() int64 {
+	var total int64
+	for _, req := range p.IO {
+		total += req.Duration
+	}
+
+	return total
+}
+
+// hasIO reports if any of the processes make I/O requests.
+func hasIO(processes []Process) bool {
+	for i := range processes {
+		if len(processes[i].IO) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseIORequests parses space separated "at:duration" pairs, e.g. "2:3 5:1", for a process into ticks at the scale.
+// Each request must come after the previous one and before the end of the burst.
+func parseIORequests(s string, burst, scale int64) ([]IORequest, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	requests := make([]IORequest, len(fields))
+	var last int64
+	for i, field := range fields {
+		at, duration, ok := strings.Cut(field, ":")
+		var errAt, errDuration error
+		requests[i].At, errAt = parseTicks(at, scale)
+		requests[i].Duration, errDuration = parseTicks(duration, scale)
+		if !ok || errAt != nil || errDuration != nil {
+			return nil, fmt.Errorf("%w: I/O request %q must be at:duration", ErrInvalidArgs, field)
+		}
+		if requests[i].At <= last || requests[i].At >= burst {
+			return nil, fmt.Errorf("%w: I/O request %q must come after %v and before the burst ends at %v",
+				ErrInvalidArgs, field, formatTime(last, scale), formatTime(burst, scale))
+		}
+		if requests[i].Duration <= 0 {
+			return nil, fmt.Errorf("%w: I/O request %q must have a positive duration", ErrInvalidArgs, field)
+		}
+		last = requests[i].At
+	}
+
+	return requests, nil
+}
+
+// pickFirst always runs the front of the ready queue.
+func pickFirst([]*runnable, int64) int {
+	return 0
+}
+
+// FCFSIOSchedule schedules processes first-come, first-serve where a process gives up the CPU when it blocks
+// for I/O, returning to the back of the ready queue once the I/O device has served it.
+func FCFSIOSchedule(title string, inputProcesses []Process, opts Options) SchedulerResult {
+	gantt, ioGantt, err := preemptiveSchedule(inputProcesses, 0, opts, pickFirst)
+	result := calculateCompletionStats(title, inputProcesses, gantt)
+	result.IOGantt = ioGantt
+	result.Err = err
+
+	return result
+}
+
+// Overlap compares a schedule's makespan against running every CPU burst and I/O request one after another.
+type Overlap struct {
+	// Serial is the total CPU and I/O time, the makespan if nothing overlapped.
+	Serial   int64
+	Makespan int64
+	// Speedup is Serial over Makespan, 1 when no I/O was hidden behind computation. It stays under 2 as the
+	// first CPU burst always runs before any I/O can start.
+	Speedup float64
+}
+
+// ioOverlap is how well the schedule hid I/O latency behind computation.
+func ioOverlap(result SchedulerResult) Overlap {
+	var overlap Overlap
+	for _, s := range result.Stats {
+		overlap.Serial += s.BurstDuration + s.ioTime()
+	}
+	overlap.Makespan = ganttMakespan(result.Gantt)
+	if m := ganttMakespan(result.IOGantt); m > overlap.Makespan {
+		overlap.Makespan = m
+	}
+	if overlap.Makespan > 0 {
+		overlap.Speedup = float64(overlap.Serial) / float64(overlap.Makespan)
+	}
+
+	return overlap
+}
+
+func outputOverlap(w io.Writer, overlap Overlap, opts Options) {
+	_, _ = fmt.Fprintf(w, "CPU/I/O overlap: %v serial in a makespan of %v, a speedup of %vx\n\n",
+		formatTime(overlap.Serial, opts.TimeScale), formatTime(overlap.Makespan, opts.TimeScale),
+		formatFloat(overlap.Speedup, opts.Precision))
+}
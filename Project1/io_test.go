@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFCFSIOSchedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		processes   []Process
+		wantGantt   []TimeSlice
+		wantIOGantt []TimeSlice
+		wantWait    []int64
+	}{
+		{
+			name: "CPU runs another process during I/O",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, IO: []IORequest{{At: 2, Duration: 3}}},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 2, Stop: 6},
+				{PID: 1, Start: 6, Stop: 8},
+			},
+			wantIOGantt: []TimeSlice{
+				{PID: 1, Start: 2, Stop: 5},
+			},
+			wantWait: []int64{1, 2},
+		},
+		{
+			name: "I/O requests serialize through the device",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, IO: []IORequest{{At: 1, Duration: 3}}},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2, IO: []IORequest{{At: 1, Duration: 3}}},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 2},
+				{PID: 1, Start: 4, Stop: 5},
+				{PID: 2, Start: 7, Stop: 8},
+			},
+			wantIOGantt: []TimeSlice{
+				{PID: 1, Start: 1, Stop: 4},
+				{PID: 2, Start: 4, Stop: 7},
+			},
+			wantWait: []int64{0, 3},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := FCFSIOSchedule("FCFS", tt.processes, DefaultOptions())
+			if !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("FCFSIOSchedule() gantt = %v, want %v", got.Gantt, tt.wantGantt)
+			}
+			if !reflect.DeepEqual(got.IOGantt, tt.wantIOGantt) {
+				t.Errorf("FCFSIOSchedule() I/O gantt = %v, want %v", got.IOGantt, tt.wantIOGantt)
+			}
+			for i := range got.Stats {
+				if got.Stats[i].Wait != tt.wantWait[i] {
+					t.Errorf("FCFSIOSchedule() process %v wait = %v, want %v",
+						got.Stats[i].ProcessID, got.Stats[i].Wait, tt.wantWait[i])
+				}
+			}
+
+			var w bytes.Buffer
+			outputResult(&w, got, DefaultOptions())
+			if !strings.Contains(w.String(), "Gantt schedule\n") || !strings.Contains(w.String(), "I/O device\n") {
+				t.Errorf("outputResult() want CPU and I/O tracks: %v", w.String())
+			}
+		})
+	}
+}
+
+func Test_parseIORequests(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		s       string
+		want    []IORequest
+		wantErr error
+	}{
+		{name: "none"},
+		{name: "one", s: "2:3", want: []IORequest{{At: 2, Duration: 3}}},
+		{name: "several", s: "1:2  3:1", want: []IORequest{{At: 1, Duration: 2}, {At: 3, Duration: 1}}},
+		{name: "malformed", s: "2-3", wantErr: ErrInvalidArgs},
+		{name: "out of order", s: "3:1 2:1", wantErr: ErrInvalidArgs},
+		{name: "at end of burst", s: "5:1", wantErr: ErrInvalidArgs},
+		{name: "zero duration", s: "2:0", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseIORequests(tt.s, 5, 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseIORequests() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseIORequests() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ioOverlap(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      Overlap
+	}{
+		{
+			// Process 2 computes through all of process 1's I/O, nearly halving the makespan.
+			name: "overlapped",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 2, IO: []IORequest{{At: 1, Duration: 8}}},
+				{ProcessID: 2, BurstDuration: 8},
+			},
+			want: Overlap{Serial: 18, Makespan: 10, Speedup: 1.8},
+		},
+		{
+			// Nothing else can run during the only process's I/O.
+			name: "serial",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 2, IO: []IORequest{{At: 1, Duration: 8}}},
+			},
+			want: Overlap{Serial: 10, Makespan: 10, Speedup: 1},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ioOverlap(FCFSIOSchedule("FCFS", tt.processes, DefaultOptions())); got != tt.want {
+				t.Errorf("ioOverlap() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"math/rand"
+)
+
+// jitterArrivals moves each process's arrival by a random amount of at most magnitude either way, never before 0,
+// so repeated runs with different seeds explore arrivals that coincide or nearly do.
+func jitterArrivals(processes []Process, magnitude int64, rng *rand.Rand) {
+	if magnitude <= 0 {
+		return
+	}
+	for i := range processes {
+		processes[i].ArrivalTime += rng.Int63n(2*magnitude+1) - magnitude
+		if processes[i].ArrivalTime < 0 {
+			processes[i].ArrivalTime = 0
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_jitterArrivals(t *testing.T) {
+	t.Parallel()
+	arrivals := []int64{0, 3, 3, 10, 50}
+	processes := make([]Process, len(arrivals))
+	for i := range processes {
+		processes[i] = Process{ProcessID: int64(i + 1), ArrivalTime: arrivals[i], BurstDuration: 2}
+	}
+
+	tests := []struct {
+		name      string
+		magnitude int64
+	}{
+		{name: "none", magnitude: 0},
+		{name: "small", magnitude: 2},
+		{name: "large", magnitude: 20},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := make([]Process, len(processes))
+			copy(got, processes)
+			jitterArrivals(got, tt.magnitude, newSeededRand(1))
+			if tt.magnitude == 0 && !reflect.DeepEqual(got, processes) {
+				t.Errorf("jitterArrivals() with no jitter = %v, want %v unchanged", got, processes)
+			}
+			moved := false
+			for i := range got {
+				delta := got[i].ArrivalTime - processes[i].ArrivalTime
+				if got[i].ArrivalTime < 0 || delta > tt.magnitude || (delta < -tt.magnitude && got[i].ArrivalTime != 0) {
+					t.Errorf("jitterArrivals() moved process %v from %v to %v, more than %v",
+						got[i].ProcessID, processes[i].ArrivalTime, got[i].ArrivalTime, tt.magnitude)
+				}
+				moved = moved || delta != 0
+			}
+			if tt.magnitude > 0 && !moved {
+				t.Errorf("jitterArrivals() with a jitter of %v didn't move any arrival", tt.magnitude)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSchedulers_dispatchLatency(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1},
+	}
+	const latency = 2
+	tests := []struct {
+		name     string
+		schedule Scheduler
+		cores    int
+	}{
+		{name: "fcfs", schedule: FCFSSchedule},
+		{name: "round-robin", schedule: RRSchedule},
+		{name: "bounded round-robin", schedule: BoundedRRSchedule},
+		{name: "sjf", schedule: SJFSchedule},
+		{name: "priority", schedule: SJFPrioritySchedule},
+		{name: "dvfs", schedule: DVFSSchedule},
+		{name: "multi-core round-robin", schedule: MultiCoreRRSchedule, cores: 2},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opts := DefaultOptions()
+			opts.Cores = tt.cores
+			base := tt.schedule(tt.name, processes, opts)
+			opts.DispatchLatency = latency
+			got := tt.schedule(tt.name, processes, opts)
+			if got.Err != nil {
+				t.Fatalf("%v error = %v", tt.name, got.Err)
+			}
+			if averageResponse(got) <= averageResponse(base) {
+				t.Errorf("%v average response = %v, want more than %v", tt.name, averageResponse(got), averageResponse(base))
+			}
+			// Every slice that doesn't carry on the one before it on its core has the latency idle before it.
+			last := make(map[int]TimeSlice)
+			for _, s := range got.Gantt {
+				previous, ok := last[s.Core]
+				switch {
+				case ok && previous.PID == s.PID && previous.Stop == s.Start:
+				case s.Start < previous.Stop+latency:
+					t.Errorf("%v slice %v starts less than %v after the core's last slice %v", tt.name, s, latency, previous)
+				}
+				last[s.Core] = s
+			}
+		})
+	}
+}
+
+func TestSchedulers_dispatchLatencyDecisions(t *testing.T) {
+	t.Parallel()
+	opts := DefaultOptions()
+	opts.DispatchLatency = 2
+
+	// Process 2 arrives while switching to process 1, which still runs once it's been switched to.
+	sjf := SJFSchedule("SJF", []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1},
+	}, opts)
+	if want := []TimeSlice{{PID: 1, Start: 2, Stop: 7}, {PID: 2, Start: 9, Stop: 10}}; !reflect.DeepEqual(sjf.Gantt, want) {
+		t.Errorf("SJFSchedule() gantt = %v, want %v", sjf.Gantt, want)
+	}
+
+	// I/O starts when the delayed burst before it ends, and the process returning from it is switched to again.
+	opts.DispatchLatency = 1
+	io := FCFSIOSchedule("FCFS", []Process{
+		{ProcessID: 1, BurstDuration: 4, IO: []IORequest{{At: 2, Duration: 3}}},
+		{ProcessID: 2, BurstDuration: 2},
+	}, opts)
+	if want := []TimeSlice{{PID: 1, Start: 1, Stop: 3}, {PID: 2, Start: 4, Stop: 6}, {PID: 1, Start: 7, Stop: 9}}; !reflect.DeepEqual(io.Gantt, want) {
+		t.Errorf("FCFSIOSchedule() gantt = %v, want %v", io.Gantt, want)
+	}
+	if want := []TimeSlice{{PID: 1, Start: 3, Stop: 6}}; !reflect.DeepEqual(io.IOGantt, want) {
+		t.Errorf("FCFSIOSchedule() I/O gantt = %v, want %v", io.IOGantt, want)
+	}
+}
+
+func TestStrideSchedule_dispatchLatency(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 6, Weight: 1},
+		{ProcessID: 2, BurstDuration: 6, Weight: 1},
+		// Arrives while switching to process 1, whose turn mustn't be lost for it.
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2, Weight: 1},
+	}
+	opts := DefaultOptions()
+	opts.DispatchLatency = 2
+	got := StrideSchedule("Stride", processes, opts).Gantt
+	if want := (TimeSlice{PID: 1, Start: 2, Stop: 3}); len(got) == 0 || got[0] != want {
+		t.Fatalf("StrideSchedule() first slice of %v, want %v", got, want)
+	}
+	// Each pick is charged once for the slice it runs, so the turns are the same as without the latency.
+	base := StrideSchedule("Stride", processes, DefaultOptions()).Gantt
+	turns := func(gantt []TimeSlice) []int64 {
+		pids := make([]int64, len(gantt))
+		for i, s := range gantt {
+			pids[i] = s.PID
+		}
+		return pids
+	}
+	if !reflect.DeepEqual(turns(got), turns(base)) {
+		t.Errorf("StrideSchedule() turns = %v, want %v as without the latency", turns(got), turns(base))
+	}
+}
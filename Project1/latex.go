@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+var latexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+)
+
+// latexEscape escapes the characters LaTeX treats specially.
+func latexEscape(s string) string {
+	return latexEscaper.Replace(s)
+}
+
+// outputLatex writes the schedule table and summary as a tabular environment,
+// followed by a tikz gantt chart if the options ask for it.
+func outputLatex(w io.Writer, result SchedulerResult, opts Options) {
+	cols := len(scheduleHeader)
+	header := make([]string, cols)
+	for i := range scheduleHeader {
+		header[i] = latexEscape(scheduleHeader[i])
+	}
+
+	// All columns are numeric so they're all right aligned.
+	_, _ = fmt.Fprintf(w, "\\begin{tabular}{%v}\n", strings.Repeat("r", cols))
+	_, _ = fmt.Fprintf(w, "\\multicolumn{%v}{c}{\\textbf{%v}} \\\\\n", cols, latexEscape(result.Title))
+	_, _ = fmt.Fprintln(w, `\hline`)
+	_, _ = fmt.Fprintf(w, "%v \\\\\n", strings.Join(header, " & "))
+	_, _ = fmt.Fprintln(w, `\hline`)
+	for _, row := range result.rows(opts.TimeScale) {
+		for i := range row {
+			row[i] = latexEscape(row[i])
+		}
+		_, _ = fmt.Fprintf(w, "%v \\\\\n", strings.Join(row, " & "))
+	}
+	_, _ = fmt.Fprintln(w, `\hline`)
+	_, _ = fmt.Fprintf(w, "\\multicolumn{%v}{r}{} & Average & Average & Throughput \\\\\n", cols-3)
+	wait, turnaround, throughput := result.averages(opts.TimeScale)
+	_, _ = fmt.Fprintf(w, "\\multicolumn{%v}{r}{} & %v & %v & %v/t \\\\\n", cols-3,
+		formatFloat(wait, opts.Precision),
+		formatFloat(turnaround, opts.Precision),
+		formatFloat(throughput, opts.Precision))
+	_, _ = fmt.Fprintln(w, `\end{tabular}`)
+
+	if opts.LatexGantt {
+		outputLatexGantt(w, result.Gantt, opts.TimeScale)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// outputLatexGantt writes the gantt as a row of tikz boxes, one unit of time per x unit.
+func outputLatexGantt(w io.Writer, gantt []TimeSlice, scale int64) {
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, `\begin{tikzpicture}[x=0.5cm, y=0.8cm]`)
+	for i := range gantt {
+		start, stop := formatTime(gantt[i].Start, scale), formatTime(gantt[i].Stop, scale)
+		_, _ = fmt.Fprintf(w, "\\draw (%v,0) rectangle (%v,1) node[midway] {%v};\n", start, stop, gantt[i].PID)
+		_, _ = fmt.Fprintf(w, "\\node[below] at (%v,0) {%v};\n", start, start)
+		if len(gantt)-1 == i {
+			_, _ = fmt.Fprintf(w, "\\node[below] at (%v,0) {%v};\n", stop, stop)
+		}
+	}
+	_, _ = fmt.Fprintln(w, `\end{tikzpicture}`)
+}
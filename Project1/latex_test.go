@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_outputLatex(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	tests := []struct {
+		name      string
+		title     string
+		gantt     bool
+		wantTitle string
+		wantTikz  bool
+	}{
+		{
+			name:      "table only",
+			title:     "First-come, first-serve",
+			wantTitle: `\textbf{First-come, first-serve}`,
+		},
+		{
+			name:      "special characters are escaped",
+			title:     "100% FCFS_#1 & more",
+			wantTitle: `\textbf{100\% FCFS\_\#1 \& more}`,
+		},
+		{
+			name:      "with tikz gantt",
+			title:     "FCFS",
+			gantt:     true,
+			wantTitle: `\textbf{FCFS}`,
+			wantTikz:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opts := DefaultOptions()
+			opts.Format = FormatLatex
+			opts.LatexGantt = tt.gantt
+
+			var w bytes.Buffer
+			outputResult(&w, FCFSSchedule(tt.title, processes, opts), opts)
+			got := w.String()
+
+			if !strings.Contains(got, `\begin{tabular}{rrrrrrr}`) {
+				t.Errorf("outputLatex() missing tabular environment: %v", got)
+			}
+			if !strings.Contains(got, tt.wantTitle) {
+				t.Errorf("outputLatex() missing title %v: %v", tt.wantTitle, got)
+			}
+			for _, row := range []string{
+				`1 & 2 & 5 & 0 & 0 & 5 & 5 \\`,
+				`2 & 1 & 9 & 3 & 2 & 11 & 14 \\`,
+				`3 & 3 & 6 & 6 & 8 & 14 & 20 \\`,
+			} {
+				if strings.Count(got, row) != 1 {
+					t.Errorf("outputLatex() want exactly one row %v: %v", row, got)
+				}
+			}
+			if strings.Contains(got, `\begin{tikzpicture}`) != tt.wantTikz {
+				t.Errorf("outputLatex() tikz = %v, want %v", !tt.wantTikz, tt.wantTikz)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+// LCFSSchedule schedules processes last-come, first-served: whenever the CPU frees up the most recently arrived
+// ready process runs to completion. Processes arriving together go by opts.TieBreak.
+func LCFSSchedule(title string, inputProcesses []Process, opts Options) SchedulerResult {
+	gantt, ioGantt, err := preemptiveSchedule(inputProcesses, 0, opts, nonPreemptive(func(ready []*runnable, _ int64) int {
+		best := 0
+		for i := 1; i < len(ready); i++ {
+			if ready[i].ArrivalTime > ready[best].ArrivalTime ||
+				(ready[i].ArrivalTime == ready[best].ArrivalTime && opts.before(ready[i].Process, ready[best].Process, false)) {
+				best = i
+			}
+		}
+		return best
+	}))
+	result := calculateCompletionStats(title, inputProcesses, gantt)
+	result.IOGantt = ioGantt
+	result.Err = err
+
+	return result
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLCFSSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 2},
+		{ProcessID: 4, ArrivalTime: 3, BurstDuration: 1},
+	}
+
+	lcfs := LCFSSchedule("LCFS", processes, DefaultOptions())
+	wantGantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 4},
+		{PID: 4, Start: 4, Stop: 5},
+		{PID: 3, Start: 5, Stop: 7},
+		{PID: 2, Start: 7, Stop: 10},
+	}
+	if !reflect.DeepEqual(lcfs.Gantt, wantGantt) {
+		t.Errorf("LCFSSchedule() gantt = %v, want %v", lcfs.Gantt, wantGantt)
+	}
+
+	// FCFS makes the last arrival wait longest, LCFS flips that onto the earliest one still waiting.
+	fcfs := FCFSSchedule("FCFS", processes, DefaultOptions())
+	waits := func(r SchedulerResult) []int64 {
+		w := make([]int64, len(r.Stats))
+		for i := range r.Stats {
+			w[i] = r.Stats[i].Wait
+		}
+		return w
+	}
+	if got, want := waits(fcfs), []int64{0, 3, 5, 6}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FCFSSchedule() waits = %v, want %v", got, want)
+	}
+	if got, want := waits(lcfs), []int64{0, 6, 3, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LCFSSchedule() waits = %v, want %v", got, want)
+	}
+}
+
+func TestLCFSSchedule_idleAndTies(t *testing.T) {
+	t.Parallel()
+	got := LCFSSchedule("LCFS", []Process{
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 1},
+		{ProcessID: 1, ArrivalTime: 3, BurstDuration: 1},
+		{ProcessID: 3, ArrivalTime: 0, BurstDuration: 1},
+	}, DefaultOptions())
+
+	want := []TimeSlice{
+		{PID: 3, Start: 0, Stop: 1},
+		{PID: 1, Start: 3, Stop: 4},
+		{PID: 2, Start: 4, Stop: 5},
+	}
+	if !reflect.DeepEqual(got.Gantt, want) {
+		t.Errorf("LCFSSchedule() gantt = %v, want %v", got.Gantt, want)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bufio"
 	"encoding/csv"
 	"errors"
 	"fmt"
@@ -10,43 +11,356 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-
-	"github.com/olekukonko/tablewriter"
 )
 
 func main() {
 	// CLI args
-	f, closeFile, err := openProcessingFile(os.Args...)
+	opts, args, err := parseOptions(os.Args...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !opts.widthGiven {
+		opts.Width = terminalWidth(os.Stdout)
+	}
+	if opts.InversionDemo {
+		outputInversionDemo(os.Stdout, opts)
+		return
+	}
+	if opts.ProducerConsumer {
+		outputProducerConsumerDemo(os.Stdout, opts)
+		return
+	}
+	if opts.BankersDemo {
+		outputBankersDemo(os.Stdout, opts)
+		return
+	}
+	if opts.LoadSweep != (LoadSweep{}) {
+		outputLoadSweep(os.Stdout, loadSweep(opts), opts)
+		return
+	}
+	if opts.Iterations > 0 {
+		outputBenchmark(os.Stdout, benchmark(opts), opts)
+		return
+	}
+	if opts.Resume != "" {
+		snapshot, err := loadSnapshot(opts.Resume)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = opts.withTimeScale(snapshot.TimeScale)
+		result := snapshot.resume(opts)
+		if result.Err != nil {
+			log.Fatalf("%v: %v", result.Title, result.Err)
+		}
+		outputResult(os.Stdout, result, opts)
+		return
+	}
+	if opts.REPL {
+		if err := repl(os.Stdin, os.Stdout, opts); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	f, closeFile, err := openProcessingFile(args...)
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer closeFile()
 
 	// Load and parse processes
-	processes, err := loadProcesses(f)
+	var (
+		processes []Process
+		scale     int64
+	)
+	format := opts.inputFormat(args[1])
+	if opts.BestEffort {
+		var skipped int
+		processes, scale, skipped, err = loadInput(f, format, opts.Columns, os.Stderr)
+		if skipped > 0 {
+			_, _ = fmt.Fprintf(os.Stderr, "Skipped %v malformed rows\n", skipped)
+		}
+	} else {
+		processes, scale, _, err = loadInput(f, format, opts.Columns, nil)
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
+	processes = limitProcesses(processes, opts.Limit)
+	opts = opts.withTimeScale(scale)
+
+	if opts.DryRun {
+		if err := dryRun(os.Stdout, processes, opts); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if opts.ExportProcesses != "" {
+		if problems := validateProcesses(processes, opts); len(problems) > 0 {
+			log.Fatalf("%v: %v", ErrInvalidArgs, strings.Join(problems, "; "))
+		}
+		if err := exportProcesses(os.Stdout, processes, opts.ExportProcesses, opts.TimeScale); err != nil {
+			log.Fatalf("%v: error exporting processes", err)
+		}
+		return
+	}
+	if err := checkArrivals(os.Stderr, processes, opts); err != nil {
+		log.Fatal(err)
+	}
+	if problems := validateProcesses(processes, opts); len(problems) > 0 {
+		log.Fatalf("%v: %v", ErrInvalidArgs, strings.Join(problems, "; "))
+	}
+	if processes, err = expandInstances(processes); err != nil {
+		log.Fatal(err)
+	}
+
+	// Jittered arrivals may be out of order, so this comes after checking the file's order and before sorting.
+	jitterArrivals(processes, opts.Jitter, newSeededRand(opts.Seed))
+	if opts.RelativeTimes {
+		relativeTimes(processes)
+	}
 
 	//Sort arrival time (Just to be safe)
-	sort.Slice(processes[:], func(a, b int) bool {
+	sortByArrival(processes, opts)
+
+	if err := checkAffinity(processes, opts.Cores); err != nil {
+		log.Fatal(err)
+	}
+
+	if opts.AssertSchedulable {
+		if err := assertSchedulable(os.Stdout, processes, opts); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if opts.Snapshot != "" {
+		snapshot, err := takeSnapshot(opts.Algorithms[0], processes, opts.SnapshotAt, opts)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := saveSnapshot(opts.Snapshot, snapshot); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if opts.InspectAt != nil {
+		inspections, err := inspectSchedule(opts.Algorithms[0], processes, opts.InspectAt, opts)
+		if err != nil {
+			log.Fatal(err)
+		}
+		outputInspections(os.Stdout, inspections, opts)
+		return
+	}
+
+	if opts.ComparePreemption {
+		comparisons := comparePreemption(processes, opts)
+		for _, c := range comparisons {
+			for _, r := range []SchedulerResult{c.Preemptive, c.NonPreemptive} {
+				if r.Err != nil {
+					log.Fatalf("%v: %v", r.Title, r.Err)
+				}
+			}
+		}
+		outputPreemptionComparison(os.Stdout, comparisons, opts)
+		return
+	}
+
+	if opts.WorkloadStats {
+		outputWorkloadBounds(os.Stdout, workloadBounds(processes, opts.Cores), opts)
+	}
+
+	var eventLog io.Writer
+	if opts.EventLog != "" {
+		f, err := os.Create(opts.EventLog)
+		if err != nil {
+			log.Fatalf("%v: error creating event log", err)
+		}
+		defer func() {
+			if err := f.Close(); err != nil {
+				log.Fatalf("%v: error closing event log", err)
+			}
+		}()
+		eventLog = f
+	}
+
+	var ganttCSV io.Writer
+	if opts.GanttCSV != "" {
+		f, err := os.Create(opts.GanttCSV)
+		if err != nil {
+			log.Fatalf("%v: error creating gantt CSV", err)
+		}
+		defer func() {
+			if err := f.Close(); err != nil {
+				log.Fatalf("%v: error closing gantt CSV", err)
+			}
+		}()
+		ganttCSV = f
+	}
+
+	if opts.RMCheck {
+		outputSchedulability(os.Stdout, rmSchedulability(processes), opts)
+	}
+
+	var reference []ResultRecord
+	if opts.ValidateAgainst != "" {
+		if reference, err = loadResultRecords(opts.ValidateAgainst); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if opts.Profile != "" {
+		stop, err := startCPUProfile(opts.Profile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer func() {
+			if err := stop(); err != nil {
+				log.Fatalf("%v: error closing CPU profile", err)
+			}
+		}()
+	}
+
+	schedulers := selectSchedulers(opts, processes)
+	outputs := runOutputs{stdout: reference == nil && !opts.MetricsOnly, events: eventLog != nil && reference == nil,
+		gantt: ganttCSV != nil && reference == nil}
+	// The animation goes straight to the terminal, so it's only drawn when the schedulers run one at a time.
+	if opts.TUI && isTerminal(os.Stdout) {
+		outputs.tui = os.Stdout
+	}
+	results := make([]SchedulerResult, 0, len(schedulers))
+	flush := func(s namedScheduler, r *schedulerRun) {
+		if r.result.Err != nil {
+			log.Fatalf("%v: %v", s.title, r.result.Err)
+		}
+		results = append(results, r.result)
+		if err := r.flush(os.Stdout, eventLog, ganttCSV); err != nil {
+			log.Fatalf("%v: error writing %v", err, s.title)
+		}
+	}
+	if opts.PinOutputOrder {
+		for i, r := range runConcurrently(schedulers, processes, opts, outputs) {
+			flush(schedulers[i], r)
+		}
+	} else {
+		for i, s := range schedulers {
+			o := outputs
+			o.ganttHeader = i == 0
+			flush(s, s.run(processes, opts, o))
+		}
+	}
+	if reference != nil {
+		if !validateResults(os.Stdout, reference, results, opts) {
+			os.Exit(1)
+		}
+		return
+	}
+	if opts.MetricsOnly {
+		if err := outputMetrics(os.Stdout, results, opts); err != nil {
+			log.Fatalf("%v: error writing metrics", err)
+		}
+		return
+	}
+	if opts.CompareFairness {
+		outputFairness(os.Stdout, results, opts)
+	}
+}
+
+// weight is the process's Weight, or 1 if it wasn't given one.
+func (p Process) weight() int64 {
+	if p.Weight < 1 {
+		return 1
+	}
+
+	return p.Weight
+}
+
+// sortByArrival sorts processes by arrival time, breaking ties with opts.TieBreak.
+// With opts.NoSafetySort it leaves them in file order.
+func sortByArrival(processes []Process, opts Options) {
+	if opts.NoSafetySort {
+		return
+	}
+	sort.SliceStable(processes, func(a, b int) bool {
+		if processes[a].ArrivalTime == processes[b].ArrivalTime {
+			return opts.before(processes[a], processes[b], false)
+		}
 		return processes[a].ArrivalTime < processes[b].ArrivalTime
 	})
+}
+
+// Scheduler computes a schedule for a slice of processes, the title is carried through to the result for output.
+type Scheduler func(title string, processes []Process, opts Options) SchedulerResult
 
-	// First-come, first-serve scheduling
-	FCFSSchedule(os.Stdout, "First-come, first-serve", processes)
+// namedScheduler pairs a scheduler with the title its output is given.
+type namedScheduler struct {
+	title    string
+	schedule Scheduler
+	// explain gives the reason for each dispatch with -explain.
+	explain explainer
+}
+
+// algorithms are the schedulers that can be picked by name with -algo.
+
+var algorithms = map[string]namedScheduler{
+	"fcfs":           {"First-come, first-serve", FCFSSchedule, explainArrival},
+	"sjf":            {"Shortest-job-first", SJFSchedule, explainRemaining},
+	"priority":       {"Priority", SJFPrioritySchedule, explainPriority},
+	"rr":             {"Round-robin", RRSchedule, explainQueue},
+	"fcfs-io":        {"First-come, first-serve (I/O)", FCFSIOSchedule, explainArrival},
+	"edf":            {"Earliest-deadline-first", EDFSchedule, explainDeadline},
+	"stride":         {"Stride", StrideSchedule, explainTickets},
+	"lcfs":           {"Last-come, first-serve", LCFSSchedule, explainLatest},
+	"rr-bounded":     {"Round-robin (bounded wait)", BoundedRRSchedule, explainQueue},
+	"drr":            {"Deficit round-robin", DeficitRRSchedule, explainQueue},
+	"mlq":            {"Multi-level queue", MLQSchedule, explainClass},
+	"fair-share":     {"Fair-share", FairShareSchedule, explainShare},
+	"dvfs":           {"Energy-aware (DVFS)", DVFSSchedule, explainArrival},
+	"spn":            {"Shortest-process-next (predicted)", SPNSchedule, explainPrediction},
+	"priority-aging": {"Preemptive priority (aging)", AgingSchedule, explainAging},
+	"hrrn":           {"Highest-response-ratio-next", HRRNSchedule, explainResponseRatio},
+	"utility":        {"Time-utility", UtilitySchedule, explainUtility},
+}
 
-	SJFSchedule(os.Stdout, "Shortest-job-first", processes)
+// defaultAlgorithms are run in order when -algo isn't given.
+var defaultAlgorithms = []string{"fcfs", "sjf", "priority", "rr"}
+
+// selectSchedulers picks which schedulers main runs for the options and processes.
+// Without -algo the defaults run, plus the I/O-aware and deadline schedulers if the processes need them.
+func selectSchedulers(opts Options, processes []Process) []namedScheduler {
+	if opts.Cores > 1 {
+		return []namedScheduler{
+			{fmt.Sprintf("Round-robin (%v cores)", opts.Cores), MultiCoreRRSchedule, explainQueue},
+		}
+	}
+
+	names := opts.Algorithms
+	if len(names) == 0 {
+		names = defaultAlgorithms
+		if hasIO(processes) {
+			names = append(names[:len(names):len(names)], "fcfs-io")
+		}
+		if hasDeadlines(processes) {
+			names = append(names[:len(names):len(names)], "edf")
+		}
+	}
 
-	SJFPrioritySchedule(os.Stdout, "Priority", processes)
+	selected := make([]namedScheduler, len(names))
+	for i, name := range names {
+		selected[i] = algorithms[name]
+	}
 
-	RRSchedule(os.Stdout, "Round-robin", processes)
+	return selected
 }
 
 func openProcessingFile(args ...string) (*os.File, func(), error) {
 	if len(args) != 2 {
 		return nil, nil, fmt.Errorf("%w: must give a scheduling file to process", ErrInvalidArgs)
 	}
+	if args[1] == stdinName {
+		return os.Stdin, func() {}, nil
+	}
 	// Read in CSV process CSV file
 	f, err := os.Open(args[1])
 	if err != nil {
@@ -67,365 +381,234 @@ type (
 		ArrivalTime   int64
 		BurstDuration int64
 		Priority      int64
+		// Affinity is a bitmask of the cores the process may run on, bit 0 being core 0. Zero means any core.
+		Affinity int64
+		// Deadline is the absolute time the process should complete by. Zero means no deadline.
+		Deadline int64
+		// IO are the requests the process makes of the I/O device, in order.
+		IO []IORequest
+		// Period is how often a periodic task releases a job of BurstDuration. Zero means it isn't periodic.
+		Period int64
+		// Weight is the process's share of the CPU for the proportional share schedulers, a bigger number
+		// being a bigger share. Zero means the default weight of 1; see weight.
+		Weight int64
+		// Batch groups processes that are measured together, see BatchStats. Zero means it isn't in a batch.
+		Batch int64
+		// Instances is how many jobs a periodic task releases, one every Period, each scheduled as its own process
+		// by expandInstances. Zero or one means just the process itself.
+		Instances int64
+		// Value is what completing the process by its deadline is worth, decaying after it, see utility.
+		// Zero means it's worth nothing.
+		Value int64
+		// Task and Instance are the periodic task an instance was expanded from and its number from 1,
+		// see expandInstances. Instance is zero for every other process.
+		Task, Instance int64
+		// Line is the process's line in a CSV input file, or its position in a JSON one, counting from 1.
+		// Zero if it wasn't loaded from a file.
+		Line int
 	}
 	TimeSlice struct {
 		PID   int64
 		Start int64
 		Stop  int64
+		// Core the slice ran on, always 0 for single core schedulers.
+		Core int
+	}
+	// ProcessStats is the timing of a single process after scheduling.
+	ProcessStats struct {
+		Process
+		Wait       int64
+		Turnaround int64
+		Completion int64
+	}
+	// SchedulerResult is everything a scheduler produces, ready for output in any format.
+	SchedulerResult struct {
+		Title         string
+		Gantt         []TimeSlice
+		Stats         []ProcessStats
+		AveWait       float64
+		AveTurnaround float64
+		Throughput    float64
+		// Cores the schedule ran on, zero or one for single core schedulers.
+		Cores int
+		// IOGantt is the use of the I/O device, empty for schedulers that treat processes as CPU bound.
+		IOGantt []TimeSlice
+		// WaitBound is the longest a process should wait between turns, zero if the scheduler doesn't bound it.
+		WaitBound int64
+		// PerClass reports the statistics of each priority class, for schedulers that queue by class.
+		PerClass bool
+		// PerPriority reports the statistics of each priority, for schedulers that order by it.
+		PerPriority bool
+		// Err is why the scheduler couldn't finish, such as ErrNoProgress, leaving the rest of the result partial.
+		Err error
+		// Predictions are each process's predicted and actual CPU bursts, for schedulers that predict them.
+		Predictions map[int64][]BurstPrediction
+		// Energy is the energy the schedule used, for schedulers that model CPU frequency, zero otherwise.
+		Energy float64
+		// Unfinished are the processes still running when the -max-time cap stopped the simulation.
+		Unfinished []int64
+		// Aborted are the processes stopped at their deadline, for -abort-at-deadline.
+		Aborted []Abort
+		// Aging is each process's effective priority over time, for schedulers that age priorities.
+		Aging map[int64][]PriorityChange
+		// ResponseRatios are the response ratios of the processes dispatched, for HRRN.
+		ResponseRatios []ResponseRatio
 	}
 )
 
+// defaultTimeQuantum is the time slice given to each process by the round-robin schedulers.
+const defaultTimeQuantum int64 = 2
+
 //region Schedulers
 
-// FCFSSchedule outputs a schedule of processes in a GANTT chart and a table of timing given:
-// • an output writer
+// FCFSSchedule schedules processes first-come, first-serve and returns a GANTT chart and a table of timing given:
 // • a title for the chart
 // • a slice of processes
-func FCFSSchedule(w io.Writer, title string, inputProcesses []Process) {
-	var processes []Process = make([]Process, len(inputProcesses))
-	copy(processes, inputProcesses)
-	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-                gantt           = make([]TimeSlice, 0)
-	)
-	for i := range processes {
-		if processes[i].ArrivalTime > 0 {
-			waitingTime = serviceTime - processes[i].ArrivalTime
-		}
-		totalWait += float64(waitingTime)
-
-		start := waitingTime + processes[i].ArrivalTime
-
-		turnaround := processes[i].BurstDuration + waitingTime
-		totalTurnaround += float64(turnaround)
-
-		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
-		lastCompletion = float64(completion)
-
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTime),
-			fmt.Sprint(turnaround),
-			fmt.Sprint(completion),
+// • the scheduling options
+func FCFSSchedule(title string, inputProcesses []Process, opts Options) SchedulerResult {
+	processes := cpuBound(inputProcesses)
+	// Processes run strictly in the order they're given, so an unsorted list runs unsorted, see -no-safety-sort.
+	var next int
+	pick := func(ready []*runnable, _ int64) int {
+		for i := range ready {
+			if next < len(processes) && ready[i].ProcessID == processes[next].ProcessID {
+				next++
+				return i
+			}
 		}
-		serviceTime += processes[i].BurstDuration
-
-		gantt = append(gantt, TimeSlice{
-			PID:   processes[i].ProcessID,
-			Start: start,
-			Stop:  serviceTime,
-		})
+		return -1
 	}
+	gantt, _, err := preemptiveSchedule(processes, 0, opts, nonPreemptive(pick))
+	result := calculateCompletionStats(title, processes, gantt)
+	result.Err = err
 
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	return result
 }
 
-func calculateAndPrintStats(w io.Writer, inputProcesses []Process, gantt []TimeSlice){
-	var processes []Process = make([]Process, len(inputProcesses))
+// cpuBound copies the processes without their I/O requests, for the schedulers that run each process
+// as a single CPU burst.
+func cpuBound(inputProcesses []Process) []Process {
+	processes := make([]Process, len(inputProcesses))
 	copy(processes, inputProcesses)
-	var (
-                totalWait       float64
-                totalTurnaround float64
-                lastCompletion  float64
-                schedule        = make([][]string, len(processes))
-        )
 	for i := range processes {
-		var computationTime int64 = 0
-		var waitingTime int64 = 0
-		var finishTime int64 = 0
-		for j := range gantt {
-			if(gantt[j].PID == processes[i].ProcessID){
-				computationTime += gantt[j].Stop - gantt[j].Start
-			}else{
-				waitingTime += gantt[j].Stop - gantt[j].Start
-			}
-			if(computationTime >= processes[i].BurstDuration){
-				finishTime = gantt[j].Stop
-				break
-			}
-		}
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTime),
-			fmt.Sprint(waitingTime + computationTime),
-			fmt.Sprint(finishTime),
-		}
-		totalWait += float64(waitingTime)
-		totalTurnaround += float64(waitingTime + computationTime)
-		if(float64(finishTime) > lastCompletion){
-			lastCompletion = float64(finishTime)
-		}
+		processes[i].IO = nil
 	}
 
-	count := float64(len(processes))
-        aveWait := totalWait / count
-        aveTurnaround := totalTurnaround / count
-        aveThroughput := count / lastCompletion
-
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	return processes
 }
 
-//Plan: do my scheduling here, and make the FCFS code calculate all the statistics
-func SJFSchedule(w io.Writer, title string, inputProcesses []Process) {
-
-	var processes []Process = make([]Process, len(inputProcesses))
-	copy(processes, inputProcesses)
+// SJFSchedule schedules processes shortest job first, preempting the running process when one arrives with
+// less left to run, and returns a GANTT chart and a table of timing given:
+// • a title for the chart
+// • a slice of processes
+// • the scheduling options
+// Processes with the same time left are ordered by opts.SJFTie, but never preempt the running process.
+func SJFSchedule(title string, inputProcesses []Process, opts Options) SchedulerResult {
+	processes := cpuBound(inputProcesses)
+	gantt, _, err := preemptiveSchedule(processes, 0, opts, pickShortestRemaining(opts, nil))
+	result := calculateCompletionStats(title, processes, gantt)
+	result.Err = err
+
+	return result
+}
 
-	var gantt = make([]TimeSlice, 0)
-	var time int64 = 0
-	var timeSlot int64 = 0 //The current running process's TimeSlice index in gantt
-	var ganttStart = func(pid int){
-		if((timeSlot > 0) && gantt[timeSlot-1].PID == processes[pid].ProcessID){
-			timeSlot--
-			return
+// pickShortestRemaining is SJFSchedule's pick, the ready process with the least time left, keeping the one
+// running on a tie. running is the process picked last, nil before any has been.
+func pickShortestRemaining(opts Options, running *runnable) func(ready []*runnable, time int64) int {
+	tieBreak := opts.sjfTieBreak()
+	return func(ready []*runnable, _ int64) int {
+		best := 0
+		for i := 1; i < len(ready); i++ {
+			switch {
+			case ready[i].Remaining < ready[best].Remaining:
+				best = i
+			case ready[i].Remaining > ready[best].Remaining, ready[best] == running:
+			case ready[i] == running || tieBreak.before(ready[i].Process, ready[best].Process, false):
+				best = i
+			}
 		}
-		gantt = append(gantt, TimeSlice{
-			PID:	processes[pid].ProcessID,
-			Start:	time,
-			Stop:	time,	//Temporary value
-		})
-	}
-	var ganttStop = func(){
-		gantt[timeSlot].Stop = time
-		timeSlot++
+		running = ready[best]
+		return best
 	}
-	var ganttSwap = func(pid int){
-		ganttStop()
-		ganttStart(pid)
-	}
-
-	//Waiting queue just holds the index of the process in the processes array
-	var waitingQueue = make([]int, 0)
-	var waitingQueueAdd = func(pid int){
-		waitingQueue = append(waitingQueue, pid)
-	}
-	var waitingQueueRemove = func() int{
-		var pid int = waitingQueue[0]
-		waitingQueue = waitingQueue[1:]
-		return pid
-	}
-
-	//We can assume processes are sorted by arrival time
-	var running int = -1
-	for i := 0; i < len(processes); i++ {
-		//This does it for i too
-		//This is to ensure that processess that appear at the same time are evaluated together
-		for arrivalTime := processes[i].ArrivalTime; (i < len(processes)) && (processes[i].ArrivalTime == arrivalTime); i++ {
-			waitingQueue = append(waitingQueue, i)
-		}
-		i--
-
-		//Should really use insertion sort here, but this is too easy
-		//Sort the items in the waiting queue by their burstDuration
-		sort.Slice(waitingQueue[:], func (a, b int) bool{
-			return processes[waitingQueue[a]].BurstDuration < processes[waitingQueue[b]].BurstDuration
-		})
-
-		var SHORTEST_JOB_IN_THE_QUEUE int = waitingQueue[0]
-
-		var PREVIOUS_TIME int64 = time
-
-		//This way, on i == 0, TIME_ELAPSED == processes[i].ArrivalTime
-		var TIME_ELAPSED int64 = processes[i].ArrivalTime - PREVIOUS_TIME
-
-		time += TIME_ELAPSED
-
-		//Elapse time of running program
-		if (running >= 0){
-			processes[running].BurstDuration -= TIME_ELAPSED
+}
 
-			if(processes[SHORTEST_JOB_IN_THE_QUEUE].BurstDuration < processes[running].BurstDuration){
-				waitingQueueAdd(running)
-				running = waitingQueueRemove()
-				ganttSwap(running)
-			}
+// SJFPrioritySchedule schedules processes shortest job first like SJFSchedule, preempting the running process
+// when one arrives with less left to run, but breaks ties between equal times left by priority and then
+// opts.TieBreak, so a queued process that ties with the running one on both can still take the CPU from it.
+func SJFPrioritySchedule(title string, inputProcesses []Process, opts Options) SchedulerResult {
+	processes := cpuBound(inputProcesses)
+	gantt, _, err := preemptiveSchedule(processes, 0, opts, pickShortestThenPriority(opts))
+	result := calculateCompletionStats(title, processes, gantt)
+	result.PerPriority = true
+	result.Err = err
+
+	return result
+}
 
-			//In theory, this shouldn't happen
-			//We shouldn't have a burst duration of 0 after a fast forward because we let the process finish processing before we fast forward
-			//if processes[running].BurstDuration <= 0 {
-				//Make note of the end time in gantt
-			//}
-		}else{
-			running = waitingQueueRemove()
-			ganttStart(running)
-		}
-
-		//If the current process won't get preempted by the next arriving process
-		//The greater than in the if statement means that if the process gets preempted, there will be at least one burst time left in the process when that new process arrives
-		//If BurstDuration + time == process[i+1].ArrivalTime, then the next TIME_ELAPSED will be 0
-		for !((i + 1) < len(processes) && (processes[running].BurstDuration) + time > processes[i+1].ArrivalTime) {
-			//We wait it out before we fast forward
-			time += processes[running].BurstDuration
-			if (len(waitingQueue) <= 0){
-				running = -1
-				ganttStop()
-				break
-			}else{
-				running = waitingQueueRemove()
-				ganttSwap(running)
+// pickShortestThenPriority is SJFPrioritySchedule's pick, the ready process with the least time left, then the
+// highest priority, then first by opts.TieBreak.
+func pickShortestThenPriority(opts Options) func(ready []*runnable, time int64) int {
+	return func(ready []*runnable, _ int64) int {
+		best := 0
+		for i := 1; i < len(ready); i++ {
+			a, b := ready[i], ready[best]
+			switch {
+			case a.Remaining != b.Remaining:
+				if a.Remaining < b.Remaining {
+					best = i
+				}
+			case a.Priority != b.Priority:
+				if opts.higherPriority(a.Priority, b.Priority) {
+					best = i
+				}
+			case opts.before(a.Process, b.Process, false):
+				best = i
 			}
 		}
+		return best
 	}
-
-	outputTitle(w, title)
-	calculateAndPrintStats(w, inputProcesses, gantt);
 }
 
-//A ton of copied code from above, avert your eyes children
-func SJFPrioritySchedule(w io.Writer, title string, inputProcesses []Process) {
+func RRSchedule(title string, inputProcesses []Process, opts Options) SchedulerResult {
 
 	var processes []Process = make([]Process, len(inputProcesses))
 	copy(processes, inputProcesses)
 
 	var gantt = make([]TimeSlice, 0)
 	var time int64 = 0
+	var timeQuantum int64 = opts.quantum()	//Shout out to this youtube lecture https://www.youtube.com/watch?v=TxjIlNYRZ5M
 	var timeSlot int64 = 0 //The current running process's TimeSlice index in gantt
-	var ganttStart = func(pid int){
-		if((timeSlot > 0) && gantt[timeSlot-1].PID == processes[pid].ProcessID){
-			timeSlot--
-			return
-		}
-		gantt = append(gantt, TimeSlice{
-			PID:	processes[pid].ProcessID,
-			Start:	time,
-			Stop:	time,	//Temporary value
-		})
-	}
-	var ganttStop = func(){
-		gantt[timeSlot].Stop = time
-		timeSlot++
-	}
-	var ganttSwap = func(pid int){
-		ganttStop()
-		ganttStart(pid)
-	}
-
-	//Waiting queue just holds the index of the process in the processes array
-	var waitingQueue = make([]int, 0)
-	var waitingQueueAdd = func(pid int){
-		waitingQueue = append(waitingQueue, pid)
-	}
-	var waitingQueueRemove = func() int{
-		var pid int = waitingQueue[0]
-		waitingQueue = waitingQueue[1:]
-		return pid
-	}
-
-	//We can assume processes are sorted by arrival time
-	var running int = -1
-	for i := 0; i < len(processes); i++ {
-		//This does it for i too
-		//This is to ensure that processess that appear at the same time are evaluated together
-		for arrivalTime := processes[i].ArrivalTime; (i < len(processes)) && (processes[i].ArrivalTime == arrivalTime); i++ {
-			waitingQueue = append(waitingQueue, i)
-		}
-		i--
-
-		//Should really use insertion sort here, but this is too easy
-		//Sort the items in the waiting queue by their burstDuration
-
-		var compare = func (a, b int) bool{
-			if((processes[a].BurstDuration) == (processes[b].BurstDuration)){
-				return ((processes[a].Priority) < (processes[b].Priority))
-			}else{
-				return ((processes[a].BurstDuration) < (processes[b].BurstDuration))
-			}
-		}
-
-		sort.Slice(waitingQueue[:], func(a, b int) bool{
-			return compare(waitingQueue[a], waitingQueue[b])
-		})
-
-		var SHORTEST_JOB_IN_THE_QUEUE int = waitingQueue[0]
 
-		var PREVIOUS_TIME int64 = time
-
-		//This way, on i == 0, TIME_ELAPSED == processes[i].ArrivalTime
-		var TIME_ELAPSED int64 = processes[i].ArrivalTime - PREVIOUS_TIME
-
-		time += TIME_ELAPSED
-
-		//Elapse time of running program
-		if (running >= 0){
-			processes[running].BurstDuration -= TIME_ELAPSED
-
-			//If running is not less than the SHORTEST_JOB_IN_THE_QUEUE
-			if(!compare(running, SHORTEST_JOB_IN_THE_QUEUE)){
-				waitingQueueAdd(running)
-				running = waitingQueueRemove()
-				ganttSwap(running)
-			}
-
-			//In theory, this shouldn't happen
-			//We shouldn't have a burst duration of 0 after a fast forward because we let the process finish processing before we fast forward
-			//if processes[running].BurstDuration <= 0 {
-				//Make note of the end time in gantt
-			//}
-		}else{
-			running = waitingQueueRemove()
-			ganttStart(running)
-		}
-
-		//If the current process won't get preempted by the next arriving process
-		//The greater than in the if statement means that if the process gets preempted, there will be at least one burst time left in the process when that new process arrives
-		//If BurstDuration + time == process[i+1].ArrivalTime, then the next TIME_ELAPSED will be 0
-		for !((i + 1) < len(processes) && (processes[running].BurstDuration) + time > processes[i+1].ArrivalTime) {
-			//We wait it out before we fast forward
-			time += processes[running].BurstDuration
-			if (len(waitingQueue) <= 0){
-				running = -1
-				ganttStop()
-				break
-			}else{
-				running = waitingQueueRemove()
-				ganttSwap(running)
-			}
+	//With opts.WeightedFair each process's quantum is the time quantum times its weight, 1 if it wasn't given one
+	var quantumOf = func(process Process) int64{
+		if(opts.WeightedFair){
+			return timeQuantum * process.weight()
 		}
+		return timeQuantum
 	}
 
-	outputTitle(w, title)
-	calculateAndPrintStats(w, inputProcesses, gantt);
-}
-
-func RRSchedule(w io.Writer, title string, inputProcesses []Process) {
-
-	var processes []Process = make([]Process, len(inputProcesses))
-	copy(processes, inputProcesses)
-
-	var gantt = make([]TimeSlice, 0)
-	var time int64 = 0
-	var timeQuantum int64 = 2	//Shout out to this youtube lecture https://www.youtube.com/watch?v=TxjIlNYRZ5M
-	var timeSlot int64 = 0 //The current running process's TimeSlice index in gantt
-
 	var totalWork int64 = 0;
 	var lastArrived int64 = 0;
+	var longestQuantum int64 = timeQuantum;
+	var dispatches int64 = 0; //At most one per quantum each process runs for
 	for i := range processes {
+		if(opts.WeightedFair && processes[i].Weight < 0){
+			result := calculateCompletionStats(title, cpuBound(inputProcesses), gantt)
+			result.Err = fmt.Errorf("%w: process %v has weight %v, weighted fair round robin can't give it a quantum",
+				ErrInvalidArgs, processes[i].ProcessID, processes[i].Weight)
+			return result
+		}
 		totalWork += processes[i].BurstDuration;
 		if(processes[i].ArrivalTime > lastArrived){
 			lastArrived = processes[i].ArrivalTime;
 		}
+		if(quantumOf(processes[i]) > longestQuantum){
+			longestQuantum = quantumOf(processes[i]);
+		}
+		dispatches += processes[i].BurstDuration/quantumOf(processes[i]) + 1;
 	}
 
-	var MAX_SIMULATION_TIME int64 = totalWork + lastArrived + timeQuantum + 1;
+	var MAX_SIMULATION_TIME int64 = totalWork + lastArrived + longestQuantum + dispatches*opts.DispatchLatency + 1;
 
 	var ganttStart = func(pid int64){
 		if((timeSlot > 0) && gantt[timeSlot-1].PID == pid){
@@ -458,10 +641,30 @@ func RRSchedule(w io.Writer, title string, inputProcesses []Process) {
 		waitingQueue = waitingQueue[1:]
 		return process
 	}
+	//Gives up with ErrNoProgress, naming the processes left, rather than looping forever
+	var watchdog = newWatchdog()
+	var stuck = func() SchedulerResult{
+		pids := make([]int64, 0, len(waitingQueue)+len(processes))
+		for _, process := range append(append([]Process{}, waitingQueue...), processes...) {
+			pids = append(pids, process.ProcessID)
+		}
+		result := calculateCompletionStats(title, cpuBound(inputProcesses), gantt)
+		result.Err = noProgress(pids, time)
+		return result
+	}
 	//We can assume processes are sorted by arrival time
 	for true {
-		if(time >= MAX_SIMULATION_TIME){
-			log.Fatalf("Round robin took longer than the maximum allowed time.");
+		if(time >= MAX_SIMULATION_TIME || watchdog.stuck(time, len(waitingQueue)+len(processes))){
+			return stuck()
+		}
+		if err := opts.cancelled(); err != nil {
+			result := calculateCompletionStats(title, cpuBound(inputProcesses), gantt)
+			result.Err = err
+			return result
+		}
+		//Stop at the -max-time cap rather than simulating what would be cut off anyways
+		if until := opts.until(); until >= 0 && time >= until {
+			break
 		}
 
 		for (len(processes) >= 1) && (processes[0].ArrivalTime <= time){
@@ -476,14 +679,18 @@ func RRSchedule(w io.Writer, title string, inputProcesses []Process) {
 			break;
 		}
 		var running Process = waitingQueueRemove()
+		//Switching to a process that wasn't just running takes the dispatch latency, with the CPU idle
+		if(!((timeSlot > 0) && gantt[timeSlot-1].PID == running.ProcessID && gantt[timeSlot-1].Stop == time)){
+			time += opts.DispatchLatency
+		}
 		ganttStart(running.ProcessID);
 
-		if(running.BurstDuration < timeQuantum){
+		if(running.BurstDuration < quantumOf(running)){
 			time += running.BurstDuration;
 			running.BurstDuration = 0
 		}else{
-			time += timeQuantum
-			running.BurstDuration -= timeQuantum
+			time += quantumOf(running)
+			running.BurstDuration -= quantumOf(running)
 		}
 
 		ganttStop();
@@ -495,47 +702,269 @@ func RRSchedule(w io.Writer, title string, inputProcesses []Process) {
 			waitingQueueAdd(running)
 		}
 	}
-	outputTitle(w, title)
-	calculateAndPrintStats(w, inputProcesses, gantt);
+	return calculateCompletionStats(title, cpuBound(inputProcesses), gantt)
 }
 
 //endregion
 
 //region Output helpers
 
+// outputResult writes a scheduler's result in the format chosen by the options.
+func outputResult(w io.Writer, result SchedulerResult, opts Options) {
+	if opts.PreserveOrder {
+		result = result.inInputOrder()
+	}
+	if opts.SummaryOnly {
+		wait, turnaround, throughput := result.averages(opts.TimeScale)
+		outputSummary(w, result.Title, wait, turnaround, throughput, opts.Precision)
+		if opts.RepeatableHash {
+			_, _ = fmt.Fprintf(w, "%v: hash %v\n", result.Title, result.hash())
+		}
+		return
+	}
+	switch opts.Format {
+	case FormatLatex:
+		outputLatex(w, result, opts)
+	case FormatJSON:
+		outputJSON(w, result, opts)
+	default:
+		outputTitle(w, result.Title)
+		if result.Cores > 1 {
+			for core := 0; core < result.Cores; core++ {
+				label := fmt.Sprintf("Gantt schedule (core %v)", core)
+				outputGanttEvents(w, label, coreGantt(result.Gantt, core), append(result.Gantt, result.IOGantt...), opts)
+			}
+		} else {
+			outputGanttEvents(w, "Gantt schedule", result.Gantt, result.IOGantt, opts)
+		}
+		if len(result.IOGantt) > 0 {
+			outputGanttEvents(w, "I/O device", result.IOGantt, result.Gantt, opts)
+		}
+		if opts.GroupBy != "" {
+			outputGroupedSchedule(w, result, opts)
+		} else {
+			wait, turnaround, throughput := result.averages(opts.TimeScale)
+			outputSchedule(w, result.rows(opts.TimeScale), wait, turnaround, throughput, opts)
+		}
+		outputUnfinished(w, result, opts)
+		outputAborted(w, result.Aborted, opts)
+		if result.Cores > 1 {
+			usage, aggregate := coreUtilization(result.Gantt, result.Cores)
+			outputCoreUtilization(w, usage, aggregate, opts)
+		} else {
+			outputIdle(w, result.Gantt, opts)
+		}
+		if len(result.IOGantt) > 0 {
+			outputOverlap(w, ioOverlap(result), opts)
+		}
+		if len(result.Predictions) > 0 {
+			outputPredictions(w, result.Predictions, opts)
+		}
+		if len(result.ResponseRatios) > 0 {
+			outputResponseRatios(w, result.ResponseRatios, opts)
+		}
+		if opts.ShowAging && result.Aging != nil {
+			outputAging(w, result.Aging, opts)
+		}
+		if result.Energy > 0 {
+			outputEnergy(w, result, opts)
+		}
+		if hasValues(result.processes()) {
+			outputUtility(w, result.Stats, opts)
+		}
+		if hasDeadlines(result.processes()) {
+			_, _, throughput := result.averages(opts.TimeScale)
+			outputRealTimeMetrics(w, realTimeMetrics(result.Stats), throughput, opts)
+		}
+		if result.WaitBound > 0 {
+			outputWaitBound(w, result, opts.TimeScale)
+		}
+		if result.PerClass {
+			outputClassStats(w, classStats(result.Stats, opts), opts)
+		}
+		if result.PerPriority {
+			outputPriorityStats(w, priorityStats(result.Stats, opts), opts)
+		}
+		if hasInstances(result.processes()) {
+			outputTaskStats(w, taskStats(result.Stats), opts)
+		}
+		if hasBatches(result.processes()) {
+			outputBatchStats(w, batchStats(result.Stats), opts)
+		}
+		if opts.Response {
+			outputResponse(w, result, opts)
+		}
+		if opts.WeightedTurnaround {
+			outputWeightedTurnaround(w, result, opts)
+		}
+		if opts.QueueLength {
+			outputReadyQueuePeak(w, result, opts)
+		}
+		if opts.CompletionOrder {
+			outputCompletionOrder(w, completionOrder(result.Stats), opts)
+		}
+		if opts.ThroughputWindow > 0 {
+			outputThroughputWindows(w, throughputWindows(result.Stats, opts.ThroughputWindow), opts)
+		}
+		if opts.windowed() {
+			outputSteadyState(w, steadyState(result, opts), opts)
+		}
+		outputStarvation(w, starvedProcesses(result.Stats, opts), opts)
+		for _, pid := range opts.WaitTimeline {
+			outputWaitTimeline(w, pid, waitTimeline(result, pid), opts)
+		}
+		if opts.RepeatableHash {
+			outputHash(w, result)
+		}
+	}
+}
+
+// processes returns the scheduled processes.
+func (r SchedulerResult) processes() []Process {
+	processes := make([]Process, len(r.Stats))
+	for i := range r.Stats {
+		processes[i] = r.Stats[i].Process
+	}
+
+	return processes
+}
+
+// inInputOrder returns the result with its stats in the order the processes were in the input file.
+func (r SchedulerResult) inInputOrder() SchedulerResult {
+	stats := make([]ProcessStats, len(r.Stats))
+	copy(stats, r.Stats)
+	sort.SliceStable(stats, func(a, b int) bool { return stats[a].Line < stats[b].Line })
+	r.Stats = stats
+
+	return r
+}
+
+// averages converts the average wait, turnaround and throughput from ticks to units of time at the scale.
+func (r SchedulerResult) averages(scale int64) (float64, float64, float64) {
+	if scale <= 1 {
+		return r.AveWait, r.AveTurnaround, r.Throughput
+	}
+	s := float64(scale)
+
+	return r.AveWait / s, r.AveTurnaround / s, r.Throughput * s
+}
+
+// rows converts the per-process stats into table rows, with times in units at the scale.
+func (r SchedulerResult) rows(scale int64) [][]string {
+	rows := make([][]string, len(r.Stats))
+	for i, s := range r.Stats {
+		rows[i] = []string{
+			fmt.Sprint(s.ProcessID),
+			fmt.Sprint(s.Priority),
+			formatTime(s.BurstDuration, scale),
+			formatTime(s.ArrivalTime, scale),
+			formatTime(s.Wait, scale),
+			formatTime(s.Turnaround, scale),
+			formatTime(s.Completion, scale),
+		}
+	}
+
+	return rows
+}
+
 func outputTitle(w io.Writer, title string) {
-	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
-	_, _ = fmt.Fprintln(w, strings.Repeat(" ", len(title)/2), title)
-	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+	_, _ = fmt.Fprintln(w, repeat("-", len(title)*2))
+	_, _ = fmt.Fprintln(w, repeat(" ", len(title)/2), title)
+	_, _ = fmt.Fprintln(w, repeat("-", len(title)*2))
+}
+
+// ganttBands splits the gantt's cells into bands no wider than width, with the opening "|", as the first and
+// past the last cell of each. Every band has at least one cell however narrow the width, and a width of zero
+// never splits them.
+func ganttBands(cells []string, width int) [][2]int {
+	bands := make([][2]int, 0)
+	start, used := 0, 1
+	for i, cell := range cells {
+		if width > 0 && i > start && used+len(cell) > width {
+			bands = append(bands, [2]int{start, i})
+			start, used = i, 1
+		}
+		used += len(cell)
+	}
+
+	return append(bands, [2]int{start, len(cells)})
+}
+
+// outputSummary writes the averages of a schedule on a single line, for -summary-only.
+func outputSummary(w io.Writer, title string, wait, turnaround, throughput float64, precision int) {
+	_, _ = fmt.Fprintf(w, "%v: average wait %v, average turnaround %v, throughput %v/t\n", title,
+		formatFloat(wait, precision), formatFloat(turnaround, precision), formatFloat(throughput, precision))
 }
 
-func outputGantt(w io.Writer, gantt []TimeSlice) {
-	_, _ = fmt.Fprintln(w, "Gantt schedule")
-	_, _ = fmt.Fprint(w, "|")
+func outputGantt(w io.Writer, label string, gantt []TimeSlice, opts Options) {
+	outputGanttEvents(w, label, gantt, nil, opts)
+}
+
+// outputGanttEvents is outputGantt where the events are the rest of the schedule, the other cores' slices and
+// the I/O device's, that split its idle cells when opts.GanttMergeIdle is off.
+func outputGanttEvents(w io.Writer, label string, gantt, events []TimeSlice, opts Options) {
+	_, _ = fmt.Fprintln(w, label)
+	// Gaps where the CPU sat idle get a cell of their own, keeping the times under the cells in order.
+	idle := splitIdle(idleSlices(gantt), events, opts)
+	if opts.CompactGantt {
+		_, _ = fmt.Fprintf(w, "%v\n\n", compactGantt(gantt, idle, opts.TimeScale))
+		return
+	}
+	cells := make([]string, 0, len(gantt)+len(idle))
+	starts := make([]int64, 0, len(gantt)+len(idle)+1)
 	for i := range gantt {
+		for len(idle) > 0 && idle[0].Stop <= gantt[i].Start {
+			cells = append(cells, "  idle  |")
+			starts = append(starts, idle[0].Start)
+			idle = idle[1:]
+		}
 		pid := fmt.Sprint(gantt[i].PID)
-		padding := strings.Repeat(" ", (8-len(pid))/2)
-		_, _ = fmt.Fprint(w, padding, pid, padding, "|")
+		fill := " "
+		if opts.Monochrome {
+			fill = string(ganttSymbol(gantt[i].PID))
+		}
+		cells = append(cells, ganttCell(pid, fill, opts.GanttJustify)+"|")
+		starts = append(starts, gantt[i].Start)
 	}
-	_, _ = fmt.Fprintln(w)
-	for i := range gantt {
-		_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Start), "\t")
-		if len(gantt)-1 == i {
-			_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Stop))
+	if len(gantt) > 0 {
+		starts = append(starts, gantt[len(gantt)-1].Stop)
+	}
+	// Each band's times end with when the next band starts, continuing the timeline across them.
+	for _, band := range ganttBands(cells, opts.Width) {
+		_, _ = fmt.Fprintln(w, "|"+strings.Join(cells[band[0]:band[1]], ""))
+		for _, start := range starts[band[0]:band[1]] {
+			_, _ = fmt.Fprint(w, formatTime(start, opts.TimeScale), "\t")
+		}
+		if band[1] < len(starts) {
+			_, _ = fmt.Fprint(w, formatTime(starts[band[1]], opts.TimeScale))
 		}
+		_, _ = fmt.Fprintf(w, "\n\n")
 	}
-	_, _ = fmt.Fprintf(w, "\n\n")
+	if opts.Monochrome {
+		outputGanttLegend(w, gantt)
+	}
+	if opts.GanttAxis {
+		outputGanttAxis(w, gantt, opts)
+	}
+}
+
+// formatFloat formats a float with a fixed number of decimal places.
+func formatFloat(f float64, precision int) string {
+	return strconv.FormatFloat(f, 'f', precision, 64)
 }
 
-func outputSchedule(w io.Writer, rows [][]string, wait, turnaround, throughput float64) {
+var scheduleHeader = []string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"}
+
+func outputSchedule(w io.Writer, rows [][]string, wait, turnaround, throughput float64, opts Options) {
+	precision := opts.Precision
 	_, _ = fmt.Fprintln(w, "Schedule table")
-	table := tablewriter.NewWriter(w)
-	table.SetHeader([]string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"})
+	table := newTable(w, opts)
+	table.SetHeader(scheduleHeader)
 	table.AppendBulk(rows)
 	table.SetFooter([]string{"", "", "", "",
-		fmt.Sprintf("Average\n%.2f", wait),
-		fmt.Sprintf("Average\n%.2f", turnaround),
-		fmt.Sprintf("Throughput\n%.2f/t", throughput)})
+		"Average\n" + formatFloat(wait, precision),
+		"Average\n" + formatFloat(turnaround, precision),
+		"Throughput\n" + formatFloat(throughput, precision) + "/t"})
 	table.Render()
 }
 
@@ -545,33 +974,210 @@ func outputSchedule(w io.Writer, rows [][]string, wait, turnaround, throughput f
 
 var ErrInvalidArgs = errors.New("invalid args")
 
-func loadProcesses(r io.Reader) ([]Process, error) {
-	rows, err := csv.NewReader(r).ReadAll()
+// utf8BOM is the byte order mark Windows tools such as Excel put at the start of UTF-8 files.
+const utf8BOM = "\xef\xbb\xbf"
+
+// skipBOM drops a leading UTF-8 byte order mark, which would otherwise end up in the first field.
+func skipBOM(r io.Reader) io.Reader {
+	br := bufio.NewReader(r)
+	if b, err := br.Peek(len(utf8BOM)); err == nil && string(b) == utf8BOM {
+		_, _ = br.Discard(len(utf8BOM))
+	}
+
+	return br
+}
+
+// loadProcesses reads processes from CSV, returning them with their times in ticks and the ticks per unit of time.
+// The CSV may start with a byte order mark and use CRLF line endings, and spaces around fields are ignored.
+// The first malformed row is an error.
+func loadProcesses(r io.Reader) ([]Process, int64, error) {
+	processes, scale, _, err := readProcesses(r, nil, nil)
+
+	return processes, scale, err
+}
+
+// loadProcessesBestEffort is loadProcesses skipping malformed rows rather than failing on them,
+// writing why each was skipped to w and returning how many were.
+func loadProcessesBestEffort(r io.Reader, w io.Writer) ([]Process, int64, int, error) {
+	return readProcesses(r, nil, w)
+}
+
+// readProcesses is loadProcesses if skipped is nil, otherwise loadProcessesBestEffort reporting to skipped,
+// reading the CSV columns in the columns' order if they aren't nil.
+func readProcesses(r io.Reader, columns []string, skipped io.Writer) ([]Process, int64, int, error) {
+	reader := csv.NewReader(skipBOM(r))
+	if skipped != nil {
+		// A row with a different number of columns is left to be skipped, not to fail the whole file.
+		reader.FieldsPerRecord = -1
+	}
+	rows, err := reader.ReadAll()
 	if err != nil {
-		return nil, fmt.Errorf("%w: reading CSV", err)
+		return nil, 0, 0, fmt.Errorf("%w: reading CSV", err)
 	}
+	for _, row := range rows {
+		for i := range row {
+			row[i] = strings.TrimSpace(row[i])
+		}
+	}
+
+	return parseRows(reorderColumns(rows, columns), "line", skipped)
+}
 
-	processes := make([]Process, len(rows))
+// parseRows parses rows of the CSV columns into processes, failing on the first malformed row if skipped is nil,
+// otherwise skipping them and reporting why to skipped. Errors name the row as the unit, such as "line", and its
+// number counting from 1.
+func parseRows(rows [][]string, unit string, skipped io.Writer) ([]Process, int64, int, error) {
+	// skips are the rows skipped so far by number, reported in order once every row has been seen.
+	skips := make(map[int]error)
+	skip := func(n int, err error) error {
+		err = fmt.Errorf("%v %v: %w", unit, n, err)
+		if skipped == nil {
+			return err
+		}
+		skips[n] = err
+		return nil
+	}
+
+	type row struct {
+		line   int
+		fields []string
+	}
+	good := make([]row, 0, len(rows))
+	times := make([]string, 0)
 	for i := range rows {
-		processes[i].ProcessID = mustStrToInt(rows[i][0])
-		processes[i].BurstDuration = mustStrToInt(rows[i][1])
-		processes[i].ArrivalTime = mustStrToInt(rows[i][2])
-		if len(rows[i]) == 4 {
-			processes[i].Priority = mustStrToInt(rows[i][3])
+		if len(rows[i]) < 3 {
+			if err := skip(i+1, fmt.Errorf("%w: %v columns, need at least ID, burst and arrival",
+				ErrInvalidArgs, len(rows[i]))); err != nil {
+				return nil, 0, 0, err
+			}
+			continue
+		}
+		rowTimes := rowTimes(rows[i])
+		if _, err := timeScale(rowTimes...); err != nil {
+			if err := skip(i+1, err); err != nil {
+				return nil, 0, 0, err
+			}
+			continue
+		}
+		good = append(good, row{line: i + 1, fields: rows[i]})
+		times = append(times, rowTimes...)
+	}
+	scale, err := timeScale(times...)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	processes := make([]Process, 0, len(good))
+	for _, row := range good {
+		p, err := parseProcess(row.fields, scale)
+		if err != nil {
+			if err := skip(row.line, err); err != nil {
+				return nil, 0, 0, err
+			}
+			continue
+		}
+		p.Line = row.line
+		processes = append(processes, p)
+	}
+	for n := 1; n <= len(rows); n++ {
+		if err, ok := skips[n]; ok {
+			_, _ = fmt.Fprintf(skipped, "Skipping %v\n", err)
 		}
 	}
 
-	return processes, nil
+	return processes, scale, len(skips), nil
 }
 
-func mustStrToInt(s string) int64 {
-	i, err := strconv.ParseInt(s, 10, 64)
+// rowTimes are the fields of a CSV row holding times, which set the time scale.
+func rowTimes(fields []string) []string {
+	times := make([]string, 0)
+	for col := range fields {
+		switch col {
+		case 1, 2, 5, 7:
+			times = append(times, fields[col])
+		case 6:
+			times = append(times, strings.FieldsFunc(fields[col], func(r rune) bool {
+				return r == ' ' || r == ':'
+			})...)
+		}
+	}
+
+	return times
+}
+
+// parseProcess parses a CSV row of at least ID, burst and arrival, with its times in ticks at the scale.
+func parseProcess(fields []string, scale int64) (Process, error) {
+	var (
+		p   Process
+		err error
+	)
+	integer := func(name, s string) int64 {
+		i, e := strconv.ParseInt(s, 10, 64)
+		if e != nil && err == nil {
+			err = fmt.Errorf("%w: %v %q isn't a whole number", ErrInvalidArgs, name, s)
+		}
+		return i
+	}
+	ticks := func(name, s string) int64 {
+		t, e := parseTicks(s, scale)
+		if e != nil && err == nil {
+			err = fmt.Errorf("%w: %v %q isn't a time", ErrInvalidArgs, name, s)
+		}
+		return t
+	}
+
+	p.ProcessID = integer("ID", fields[0])
+	p.BurstDuration = ticks("burst", fields[1])
+	p.ArrivalTime = ticks("arrival", fields[2])
+	if len(fields) >= 4 {
+		p.Priority = integer("priority", fields[3])
+	}
+	if len(fields) >= 5 {
+		p.Affinity = integer("affinity", fields[4])
+	}
+	if len(fields) >= 6 {
+		p.Deadline = ticks("deadline", fields[5])
+	}
+	if len(fields) >= 8 {
+		p.Period = ticks("period", fields[7])
+	}
+	if len(fields) >= 9 {
+		p.Weight = integer("weight", fields[8])
+	}
+	if len(fields) >= 10 {
+		p.Batch = integer("batch", fields[9])
+	}
+	if len(fields) >= 11 {
+		p.Instances = integer("instances", fields[10])
+	}
+	var nice int64
+	if len(fields) >= 12 && fields[11] != "" {
+		nice = integer("nice", fields[11])
+	}
+	if len(fields) >= 13 {
+		p.Value = integer("value", fields[12])
+	}
 	if err != nil {
-		_, _ = fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		return Process{}, err
+	}
+	if p.Weight < 0 {
+		return Process{}, fmt.Errorf("%w: process %v has a negative weight %v", ErrInvalidArgs, p.ProcessID, p.Weight)
+	}
+	if len(fields) >= 12 && fields[11] != "" {
+		if p.Weight != 0 {
+			return Process{}, fmt.Errorf("%w: process %v has both a weight and a nice value", ErrInvalidArgs, p.ProcessID)
+		}
+		if p.Weight, err = niceWeight(nice); err != nil {
+			return Process{}, fmt.Errorf("process %v: %w", p.ProcessID, err)
+		}
+	}
+	if len(fields) >= 7 {
+		if p.IO, err = parseIORequests(fields[6], p.BurstDuration, scale); err != nil {
+			return Process{}, fmt.Errorf("process %v: %w", p.ProcessID, err)
+		}
 	}
 
-	return i
+	return p, nil
 }
 
 //endregion
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path"
 	"reflect"
+	"sort"
 	"strings"
 	"testing"
 	"testing/iotest"
@@ -56,7 +57,7 @@ func TestFCFSSchedule(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			var w bytes.Buffer
-			FCFSSchedule(&w, tt.args.title, tt.args.processes)
+			outputResult(&w, FCFSSchedule(tt.args.title, tt.args.processes, DefaultOptions()), DefaultOptions())
 			if got := w.String(); got != tt.wantOut {
 				t.Errorf("FCFSSchedule() = %v, want %v", got, tt.wantOut)
 			}
@@ -82,6 +83,13 @@ func Test_loadProcesses(t *testing.T) {
 			},
 			wantErr: io.ErrUnexpectedEOF,
 		},
+		{
+			name: "too few columns",
+			args: args{
+				r: strings.NewReader(`1,5`),
+			},
+			wantErr: ErrInvalidArgs,
+		},
 		{
 			name: "success",
 			args: args{
@@ -92,21 +100,103 @@ func Test_loadProcesses(t *testing.T) {
 			want: []Process{
 				{
 					ProcessID:     1,
+					Line:          1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Priority:      2,
+				},
+				{
+					ProcessID:     2,
+					Line:          2,
+					ArrivalTime:   3,
+					BurstDuration: 9,
+					Priority:      1,
+				},
+				{
+					ProcessID:     3,
+					Line:          3,
+					ArrivalTime:   3,
+					BurstDuration: 6,
+					Priority:      3,
+				},
+			},
+		},
+		{
+			name: "weight beside priority",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,0,0,,0,4
+2,9,3,1,0,0,,0,0
+3,6,3,3,0,0,,0,1`),
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					Line:          1,
 					ArrivalTime:   0,
 					BurstDuration: 5,
 					Priority:      2,
+					Weight:        4,
 				},
 				{
 					ProcessID:     2,
+					Line:          2,
 					ArrivalTime:   3,
 					BurstDuration: 9,
 					Priority:      1,
 				},
 				{
 					ProcessID:     3,
+					Line:          3,
 					ArrivalTime:   3,
 					BurstDuration: 6,
 					Priority:      3,
+					Weight:        1,
+				},
+			},
+		},
+		{
+			name: "BOM and CRLF",
+			args: args{
+				r: strings.NewReader("\xef\xbb\xbf1,5,0,2\r\n2,9,3,1\r\n"),
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					Line:          1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Priority:      2,
+				},
+				{
+					ProcessID:     2,
+					Line:          2,
+					ArrivalTime:   3,
+					BurstDuration: 9,
+					Priority:      1,
+				},
+			},
+		},
+		{
+			name: "affinity column",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,1
+2,9,3,1,0`),
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					Line:          1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Priority:      2,
+					Affinity:      1,
+				},
+				{
+					ProcessID:     2,
+					Line:          2,
+					ArrivalTime:   3,
+					BurstDuration: 9,
+					Priority:      1,
 				},
 			},
 		},
@@ -115,7 +205,7 @@ func Test_loadProcesses(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got, err := loadProcesses(tt.args.r)
+			got, _, err := loadProcesses(tt.args.r)
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("loadProcesses() = %v, want %v", got, tt.want)
 			}
@@ -205,3 +295,483 @@ func Test_openProcessingFile1(t *testing.T) {
 		})
 	}
 }
+
+func Test_selectSchedulers(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		opts      func(*Options)
+		processes []Process
+		want      []string
+	}{
+		{
+			name: "defaults",
+			want: []string{"First-come, first-serve", "Shortest-job-first", "Priority", "Round-robin"},
+		},
+		{
+			name: "defaults with I/O and deadlines",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 4, Deadline: 5, IO: []IORequest{{At: 1, Duration: 1}}},
+			},
+			want: []string{
+				"First-come, first-serve", "Shortest-job-first", "Priority", "Round-robin",
+				"First-come, first-serve (I/O)", "Earliest-deadline-first",
+			},
+		},
+		{
+			name: "chosen algorithms in order",
+			opts: func(o *Options) { o.Algorithms = []string{"stride", "fcfs"} },
+			want: []string{"Stride", "First-come, first-serve"},
+		},
+		{
+			name: "multi-core",
+			opts: func(o *Options) { o.Cores = 2 },
+			want: []string{"Round-robin (2 cores)"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opts := DefaultOptions()
+			if tt.opts != nil {
+				tt.opts(&opts)
+			}
+			got := make([]string, 0)
+			for _, s := range selectSchedulers(opts, tt.processes) {
+				got = append(got, s.title)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("selectSchedulers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputResult_precision(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	tests := []struct {
+		name      string
+		precision int
+		format    string
+		want      []string
+	}{
+		{
+			name:      "text with 4 places",
+			precision: 4,
+			format:    FormatText,
+			want:      []string{"3.3333", "10.0000", "0.1500/T"},
+		},
+		{
+			name:      "text with no places",
+			precision: 0,
+			format:    FormatText,
+			want:      []string{"|                                      3    |     10     |    0/T     |"},
+		},
+		{
+			name:      "latex with 4 places",
+			precision: 4,
+			format:    FormatLatex,
+			want:      []string{`& 3.3333 & 10.0000 & 0.1500/t \\`},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opts := DefaultOptions()
+			opts.Precision = tt.precision
+			opts.Format = tt.format
+
+			var w bytes.Buffer
+			outputResult(&w, FCFSSchedule("FCFS", processes, opts), opts)
+			for _, want := range tt.want {
+				if !strings.Contains(w.String(), want) {
+					t.Errorf("outputResult() missing %q: %v", want, w.String())
+				}
+			}
+		})
+	}
+}
+
+func Test_outputResult_preserveOrder(t *testing.T) {
+	t.Parallel()
+	processes, _, err := loadProcesses(strings.NewReader("3,2,6\n1,5,0\n2,3,4"))
+	if err != nil {
+		t.Fatalf("loadProcesses() unexpected error: %v", err)
+	}
+	sort.Slice(processes, func(a, b int) bool { return processes[a].ArrivalTime < processes[b].ArrivalTime })
+
+	tests := []struct {
+		name      string
+		preserve  bool
+		wantOrder []string
+	}{
+		{name: "scheduled order", wantOrder: []string{"|  1 |", "|  2 |", "|  3 |"}},
+		{name: "file order", preserve: true, wantOrder: []string{"|  3 |", "|  1 |", "|  2 |"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opts := DefaultOptions()
+			opts.PreserveOrder = tt.preserve
+			var w bytes.Buffer
+			outputResult(&w, FCFSSchedule("FCFS", processes, opts), opts)
+			last := -1
+			for _, row := range tt.wantOrder {
+				i := strings.Index(w.String(), row)
+				if i <= last {
+					t.Fatalf("outputResult() rows not in order %v, got:\n%v", tt.wantOrder, w.String())
+				}
+				last = i
+			}
+		})
+	}
+}
+
+func Test_outputResult_summaryOnly(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	opts := DefaultOptions()
+	opts.SummaryOnly = true
+	var w bytes.Buffer
+	outputResult(&w, FCFSSchedule("FCFS", processes, opts), opts)
+	want := "FCFS: average wait 3.33, average turnaround 10.00, throughput 0.15/t\n"
+	if w.String() != want {
+		t.Errorf("outputResult() = %q, want %q", w.String(), want)
+	}
+}
+
+func TestFCFSSchedule_lateArrival(t *testing.T) {
+	t.Parallel()
+	// Everything else completes by 50, long before process 3 arrives at 1000.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 20},
+		{ProcessID: 2, ArrivalTime: 5, BurstDuration: 30},
+		{ProcessID: 3, ArrivalTime: 1000, BurstDuration: 5},
+	}
+	result := FCFSSchedule("FCFS", processes, DefaultOptions())
+	if want := (TimeSlice{PID: 3, Start: 1000, Stop: 1005}); result.Gantt[2] != want {
+		t.Errorf("FCFSSchedule() late arrival ran %v, want %v", result.Gantt[2], want)
+	}
+	late := result.Stats[2]
+	if late.Wait != 0 || late.Turnaround != late.BurstDuration || late.Completion != 1005 {
+		t.Errorf("FCFSSchedule() late arrival stats = %+v, want no wait and a turnaround of its burst", late)
+	}
+	if want := 3.0 / 1005; result.Throughput != want {
+		t.Errorf("FCFSSchedule() throughput = %v, want %v", result.Throughput, want)
+	}
+
+	var w bytes.Buffer
+	outputGantt(&w, "Gantt schedule", result.Gantt, DefaultOptions())
+	if want := "|   1   |   2   |  idle  |   3   |\n0\t20\t50\t1000\t1005\n"; !strings.Contains(w.String(), want) {
+		t.Errorf("outputGantt() = %q, should fill the gap with an idle cell %q", w.String(), want)
+	}
+}
+
+func TestFCFSSchedule_idleGap(t *testing.T) {
+	t.Parallel()
+	// Process 2 arrives after process 1 has finished, and process 3 while process 2 is running.
+	result := FCFSSchedule("FCFS", []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 6, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 7, BurstDuration: 1},
+	}, DefaultOptions())
+
+	wantGantt := []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 6, Stop: 8}, {PID: 3, Start: 8, Stop: 9}}
+	if !reflect.DeepEqual(result.Gantt, wantGantt) {
+		t.Errorf("FCFSSchedule() gantt = %v, want %v", result.Gantt, wantGantt)
+	}
+	for i, want := range []int64{0, 0, 1} {
+		if got := result.Stats[i].Wait; got != want {
+			t.Errorf("FCFSSchedule() process %v wait = %v, want %v", result.Stats[i].ProcessID, got, want)
+		}
+	}
+	if want := 1.0 / 3; result.AveWait != want {
+		t.Errorf("FCFSSchedule() average wait = %v, want %v", result.AveWait, want)
+	}
+}
+
+func TestRRSchedule_weightedFair(t *testing.T) {
+	t.Parallel()
+	// Each process needs two turns at its weighted quantum.
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 4, Weight: 1},
+		{ProcessID: 2, BurstDuration: 12, Weight: 3},
+		{ProcessID: 3, BurstDuration: 8, Weight: 2},
+	}
+	opts := DefaultOptions()
+	opts.WeightedFair = true
+	result := RRSchedule("RR", processes, opts)
+	if result.Err != nil {
+		t.Fatalf("RRSchedule() unexpected error: %v", result.Err)
+	}
+
+	wantGantt := []TimeSlice{
+		{PID: 3, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 10}, {PID: 1, Start: 10, Stop: 12},
+		{PID: 3, Start: 12, Stop: 16}, {PID: 2, Start: 16, Stop: 22}, {PID: 1, Start: 22, Stop: 24},
+	}
+	if !reflect.DeepEqual(result.Gantt, wantGantt) {
+		t.Errorf("RRSchedule() weighted fair gantt = %v, want %v", result.Gantt, wantGantt)
+	}
+	// The rounds go in the same order as plain round robin's.
+	plain := RRSchedule("RR", processes, DefaultOptions()).Gantt
+	for i := range processes {
+		if result.Gantt[i].PID != plain[i].PID {
+			t.Errorf("RRSchedule() weighted fair round order %v, want plain round robin's %v", result.Gantt[:3], plain[:3])
+			break
+		}
+	}
+}
+
+func TestRRSchedule_weightedFairWeights(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		weight    int64
+		wantGantt []TimeSlice
+		wantErr   error
+	}{
+		{
+			name:   "zero is weight 1",
+			weight: 0,
+			wantGantt: []TimeSlice{
+				{PID: 2, Start: 0, Stop: 2}, {PID: 1, Start: 2, Stop: 6}, {PID: 2, Start: 6, Stop: 8},
+			},
+		},
+		{name: "negative", weight: -2, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opts := DefaultOptions()
+			opts.WeightedFair = true
+			result := RRSchedule("RR", []Process{
+				{ProcessID: 1, BurstDuration: 4, Weight: 2},
+				{ProcessID: 2, BurstDuration: 4, Weight: tt.weight},
+			}, opts)
+			if !errors.Is(result.Err, tt.wantErr) {
+				t.Fatalf("RRSchedule() weight %v error = %v, want %v", tt.weight, result.Err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(result.Gantt, tt.wantGantt) {
+				t.Errorf("RRSchedule() weight %v gantt = %v, want %v", tt.weight, result.Gantt, tt.wantGantt)
+			}
+		})
+	}
+}
+
+func TestFCFSSchedule_noSafetySort(t *testing.T) {
+	t.Parallel()
+	// Process 1 is first in the file but arrives after process 2.
+	processes, _, err := loadProcesses(strings.NewReader("1,2,4\n2,3,1"))
+	if err != nil {
+		t.Fatalf("loadProcesses() unexpected error: %v", err)
+	}
+	opts := DefaultOptions()
+	opts.NoSafetySort = true
+	sortByArrival(processes, opts)
+
+	want := []TimeSlice{{PID: 1, Start: 4, Stop: 6}, {PID: 2, Start: 6, Stop: 9}}
+	if got := FCFSSchedule("FCFS", processes, opts).Gantt; !reflect.DeepEqual(got, want) {
+		t.Errorf("FCFSSchedule() in file order = %v, want %v", got, want)
+	}
+}
+
+func TestSchedulers_sharedEngine(t *testing.T) {
+	t.Parallel()
+	// Processes 1 and 2 arrive together, process 4 arrives shorter than what process 3 has left.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 4, BurstDuration: 6},
+		{ProcessID: 4, ArrivalTime: 6, BurstDuration: 1},
+	}
+	tests := []struct {
+		name      string
+		schedule  func(string, []Process, Options) SchedulerResult
+		wantGantt []TimeSlice
+		wantWait  []int64
+	}{
+		{
+			// Process 2 starts once process 1 completes instead of overlapping it, and the CPU idles until
+			// process 3 arrives.
+			name:      "FCFS",
+			schedule:  FCFSSchedule,
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 5}, {PID: 3, Start: 5, Stop: 11}, {PID: 4, Start: 11, Stop: 12}},
+			wantWait:  []int64{0, 3, 1, 5},
+		},
+		{
+			// Waiting is counted from arrival, so the idle time before process 3 arrives isn't any of its wait.
+			name:      "SJF",
+			schedule:  SJFSchedule,
+			wantGantt: []TimeSlice{{PID: 2, Start: 0, Stop: 2}, {PID: 1, Start: 2, Stop: 5}, {PID: 3, Start: 5, Stop: 6}, {PID: 4, Start: 6, Stop: 7}, {PID: 3, Start: 7, Stop: 12}},
+			wantWait:  []int64{2, 0, 2, 0},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := tt.schedule(tt.name, processes, DefaultOptions())
+			if !reflect.DeepEqual(result.Gantt, tt.wantGantt) {
+				t.Errorf("%v gantt = %v, want %v", tt.name, result.Gantt, tt.wantGantt)
+			}
+			for i, s := range result.Stats {
+				if s.Wait != tt.wantWait[i] || s.Turnaround != s.Completion-s.ArrivalTime {
+					t.Errorf("%v process %v stats = %+v, want a wait of %v", tt.name, s.ProcessID, s, tt.wantWait[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSchedulers_waitingTime(t *testing.T) {
+	t.Parallel()
+	// No process is preempted under FCFS, so each one's wait is just from its arrival to its only slice.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 2, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 2, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 12, BurstDuration: 3},
+		{ProcessID: 4, ArrivalTime: 13, BurstDuration: 1, Priority: 2},
+	}
+	fcfs := FCFSSchedule("FCFS", processes, DefaultOptions())
+	shared := calculateCompletionStats("FCFS", processes, fcfs.Gantt)
+	for i, s := range fcfs.Stats {
+		if want := fcfs.Gantt[i].Start - s.ArrivalTime; s.Wait != want || shared.Stats[i].Wait != want {
+			t.Errorf("process %v waited %v in FCFS and %v by calculateCompletionStats(), want %v",
+				s.ProcessID, s.Wait, shared.Stats[i].Wait, want)
+		}
+	}
+
+	// Every scheduler waits the same way, the time it was ready but not running.
+	for name, s := range algorithms {
+		result := s.schedule(s.title, processes, DefaultOptions())
+		for _, st := range result.Stats {
+			var ran int64
+			for _, slice := range result.Gantt {
+				if slice.PID == st.ProcessID {
+					ran += slice.Stop - slice.Start
+				}
+			}
+			if want := st.Completion - st.ArrivalTime - ran; st.Wait != want {
+				t.Errorf("%v process %v waited %v, want %v", name, st.ProcessID, st.Wait, want)
+			}
+		}
+	}
+}
+
+func TestSchedulers_simultaneousEvents(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		// want is the process each scheduler dispatches at the coincident instant.
+		want map[string]int64
+		at   int64
+	}{
+		{
+			// Process 1 completes at 3 just as process 2 arrives, which is shorter and higher priority than
+			// process 3, already waiting.
+			name: "completion and arrival",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Priority: 2},
+				{ProcessID: 3, ArrivalTime: 1, BurstDuration: 4, Priority: 2},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 2, Priority: 1},
+			},
+			want: map[string]int64{"sjf": 2, "priority": 2, "lcfs": 2, "fcfs": 3},
+			at:   3,
+		},
+		{
+			// Process 1's quantum expires at 2 just as process 2 arrives, which queues ahead of it. Priority 2
+			// puts both in mlq's round-robin class.
+			name: "quantum expiry and arrival",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 2},
+				{ProcessID: 2, ArrivalTime: 2, BurstDuration: 2, Priority: 2},
+			},
+			want: map[string]int64{"rr": 2, "rr-bounded": 2, "drr": 2, "mlq": 2},
+			at:   2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			for _, name := range algorithmNames() {
+				s := algorithms[name]
+				gantt := s.schedule(s.title, tt.processes, DefaultOptions()).Gantt
+				for _, slice := range gantt {
+					if slice.Stop <= slice.Start {
+						t.Errorf("%v gantt = %v, has an empty slice", name, gantt)
+					}
+				}
+				want, ok := tt.want[name]
+				if !ok {
+					continue
+				}
+				var got int64 = -1
+				for _, slice := range gantt {
+					if slice.Start <= tt.at && tt.at < slice.Stop {
+						got = slice.PID
+					}
+				}
+				if got != want {
+					t.Errorf("%v ran %v at %v, want %v: %v", name, got, tt.at, want, gantt)
+				}
+			}
+		})
+	}
+}
+
+func Test_loadProcesses_badRow(t *testing.T) {
+	t.Parallel()
+	// The second row's burst isn't a number.
+	const csv = "1,5,0\n2,x,1\n3,2,7"
+
+	if _, _, err := loadProcesses(strings.NewReader(csv)); !errors.Is(err, ErrInvalidArgs) ||
+		!strings.Contains(err.Error(), "line 2") {
+		t.Errorf("loadProcesses() error = %v, want %v on line 2", err, ErrInvalidArgs)
+	}
+
+	var w bytes.Buffer
+	got, _, skipped, err := loadProcessesBestEffort(strings.NewReader(csv), &w)
+	if err != nil {
+		t.Fatalf("loadProcessesBestEffort() unexpected error: %v", err)
+	}
+	want := []Process{
+		{ProcessID: 1, BurstDuration: 5, Line: 1},
+		{ProcessID: 3, BurstDuration: 2, ArrivalTime: 7, Line: 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("loadProcessesBestEffort() = %v, want %v", got, want)
+	}
+	if skipped != 1 {
+		t.Errorf("loadProcessesBestEffort() skipped %v rows, want 1", skipped)
+	}
+	if want := "Skipping line 2: invalid args: burst \"x\" isn't a time\n"; w.String() != want {
+		t.Errorf("loadProcessesBestEffort() reported %q, want %q", w.String(), want)
+	}
+}
+
+func Test_loadProcessesBestEffort_shortRow(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	got, _, skipped, err := loadProcessesBestEffort(strings.NewReader("1,5,0,2\n2,9\n3,6,3,3"), &w)
+	if err != nil {
+		t.Fatalf("loadProcessesBestEffort() unexpected error: %v", err)
+	}
+	if len(got) != 2 || skipped != 1 || !strings.Contains(w.String(), "line 2") {
+		t.Errorf("loadProcessesBestEffort() = %v skipping %v with %q, want line 2 skipped", got, skipped, w.String())
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// capped returns the result as it stood when the clock reached limit: the gantts are cut off there, the stats and
+// averages only cover the processes that had finished, and the rest are listed as Unfinished.
+func (r SchedulerResult) capped(limit int64) SchedulerResult {
+	r.Gantt = truncateGantt(r.Gantt, limit)
+	r.IOGantt = truncateGantt(r.IOGantt, limit)
+
+	ran := make(map[int64]int64)
+	for _, s := range r.Gantt {
+		ran[s.PID] += s.Stop - s.Start
+	}
+	stats := make([]ProcessStats, 0, len(r.Stats))
+	r.Unfinished = make([]int64, 0)
+	for _, s := range r.Stats {
+		if s.Completion > limit || ran[s.ProcessID] < s.BurstDuration {
+			r.Unfinished = append(r.Unfinished, s.ProcessID)
+			continue
+		}
+		stats = append(stats, s)
+	}
+
+	return r.withStats(stats)
+}
+
+// withStats returns the result with only the stats given, and the averages recalculated over them.
+func (r SchedulerResult) withStats(stats []ProcessStats) SchedulerResult {
+	var (
+		totalWait       float64
+		totalTurnaround float64
+		lastCompletion  float64
+	)
+	for _, s := range stats {
+		totalWait += float64(s.Wait)
+		totalTurnaround += float64(s.Turnaround)
+		if float64(s.Completion) > lastCompletion {
+			lastCompletion = float64(s.Completion)
+		}
+	}
+	r.Stats = stats
+	r.AveWait, r.AveTurnaround, r.Throughput = 0, 0, 0
+	if count := float64(len(stats)); count > 0 {
+		r.AveWait = totalWait / count
+		r.AveTurnaround = totalTurnaround / count
+		r.Throughput = count / lastCompletion
+	}
+
+	return r
+}
+
+// truncateGantt returns the parts of the slices before limit.
+func truncateGantt(gantt []TimeSlice, limit int64) []TimeSlice {
+	truncated := make([]TimeSlice, 0, len(gantt))
+	for _, s := range gantt {
+		if s.Start >= limit {
+			continue
+		}
+		if s.Stop > limit {
+			s.Stop = limit
+		}
+		truncated = append(truncated, s)
+	}
+
+	return truncated
+}
+
+// outputUnfinished lists the processes that hadn't finished by the -max-time cap.
+func outputUnfinished(w io.Writer, result SchedulerResult, opts Options) {
+	if len(result.Unfinished) == 0 {
+		return
+	}
+	pids := make([]string, len(result.Unfinished))
+	for i, pid := range result.Unfinished {
+		pids[i] = fmt.Sprint(pid)
+	}
+	_, _ = fmt.Fprintf(w, "Stopped at the time cap of %v with processes %v unfinished\n\n",
+		formatTime(opts.MaxTime, opts.TimeScale), strings.Join(pids, ", "))
+}
+
+// until is when the engine stops for -max-time, or -1 to run to completion.
+func (o Options) until() int64 {
+	if o.MaxTime > 0 {
+		return o.MaxTime
+	}
+
+	return -1
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSchedulerResult_capped(t *testing.T) {
+	t.Parallel()
+	// Far too long to simulate to the end a quantum at a time, so the schedulers must stop at the cap.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 1 << 40},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 1 << 40},
+	}
+	opts := DefaultOptions()
+	opts.MaxTime = 12
+	tests := []struct {
+		name     string
+		schedule Scheduler
+	}{
+		{name: "round-robin", schedule: RRSchedule},
+		{name: "bounded round-robin", schedule: BoundedRRSchedule},
+		{name: "multi-core round-robin", schedule: MultiCoreRRSchedule},
+		{name: "priority", schedule: SJFPrioritySchedule},
+		{name: "dvfs", schedule: DVFSSchedule},
+		{name: "stride", schedule: StrideSchedule},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			halted := tt.schedule(tt.name, processes, opts)
+			ran := make(map[int64]int64)
+			for _, s := range halted.Gantt {
+				ran[s.PID] += s.Stop - s.Start
+			}
+			if ran[2] >= processes[1].BurstDuration && ran[3] >= processes[2].BurstDuration {
+				t.Fatalf("%v ran every process to completion, want it halted at the cap", tt.name)
+			}
+
+			got := halted.capped(opts.MaxTime)
+			if n := len(got.Gantt); n == 0 || got.Gantt[n-1].Stop != opts.MaxTime {
+				t.Errorf("capped() gantt = %v, want it to stop at %v", got.Gantt, opts.MaxTime)
+			}
+			if want := []int64{2, 3}; !reflect.DeepEqual(got.Unfinished, want) {
+				t.Errorf("capped() unfinished = %v, want %v", got.Unfinished, want)
+			}
+			if len(got.Stats) != 1 || got.Stats[0].ProcessID != 1 || got.AveTurnaround != float64(got.Stats[0].Turnaround) {
+				t.Errorf("capped() stats = %v, average turnaround %v, want only process 1's", got.Stats, got.AveTurnaround)
+			}
+		})
+	}
+}
+
+func Test_truncateGantt(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 8}, {PID: 1, Start: 8, Stop: 9}}
+	want := []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 6}}
+	if got := truncateGantt(gantt, 6); !reflect.DeepEqual(got, want) {
+		t.Errorf("truncateGantt() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// Priority classes of the multi-level queue scheduler, highest first.
+const (
+	ClassSystem = iota
+	ClassInteractive
+	ClassBatch
+)
+
+// classNames are the priority classes as output.
+var classNames = []string{"System", "Interactive", "Batch"}
+
+// priorityClass assigns a process its fixed class from its priority: 1 or less is system,
+// 2 is interactive, and 3 or more is batch. With PriorityDescending the classes are the other way round,
+// 3 or more being system and 1 or less batch.
+func priorityClass(p Process, opts Options) int {
+	switch {
+	case p.Priority == 2:
+		return ClassInteractive
+	case opts.higherPriority(p.Priority, 2):
+		return ClassSystem
+	default:
+		return ClassBatch
+	}
+}
+
+// MLQSchedule schedules processes from a queue per priority class, never moving a process between queues.
+// The highest class with a ready process always runs, preempting any lower class. The system and batch queues
+// are first-come, first-serve, and the interactive queue is round-robin.
+func MLQSchedule(title string, inputProcesses []Process, opts Options) SchedulerResult {
+	// rr is the interactive queue's round order, its front taking a turn that started at turnStart.
+	var (
+		rr        = make([]*runnable, 0)
+		current   *runnable
+		turnStart int64
+	)
+	gantt, ioGantt, err := preemptiveSchedule(inputProcesses, opts.quantum(), opts, func(ready []*runnable, time int64) int {
+		isReady := make(map[*runnable]bool, len(ready))
+		for _, r := range ready {
+			isReady[r] = true
+		}
+		kept := rr[:0]
+		queued := make(map[*runnable]bool, len(rr))
+		for _, r := range rr {
+			if isReady[r] {
+				kept = append(kept, r)
+				queued[r] = true
+			}
+		}
+		rr = kept
+		for _, r := range ready {
+			if !queued[r] && priorityClass(r.Process, opts) == ClassInteractive {
+				rr = append(rr, r)
+			}
+		}
+		if len(rr) > 0 && rr[0] == current && time-turnStart >= opts.quantum() {
+			rr, current = append(rr[1:], current), nil
+		}
+
+		class := ClassBatch
+		for _, r := range ready {
+			if c := priorityClass(r.Process, opts); c < class {
+				class = c
+			}
+		}
+		if class != ClassInteractive {
+			// Another queue running ends the interactive turn, the interrupted process gets a fresh one.
+			current = nil
+			for i, r := range ready {
+				if priorityClass(r.Process, opts) == class {
+					return i
+				}
+			}
+		}
+
+		if rr[0] != current {
+			current, turnStart = rr[0], time
+		}
+		for i := range ready {
+			if ready[i] == current {
+				return i
+			}
+		}
+
+		return 0
+	})
+	result := calculateCompletionStats(title, inputProcesses, gantt)
+	result.IOGantt = ioGantt
+	result.Err = err
+	result.PerClass = true
+
+	return result
+}
+
+// ClassStats are the averages of the processes in one priority class.
+type ClassStats struct {
+	Class         int
+	Processes     int
+	AveWait       float64
+	AveTurnaround float64
+}
+
+// classStats averages the stats of each priority class that has any processes, highest class first.
+func classStats(stats []ProcessStats, opts Options) []ClassStats {
+	classes := make([]ClassStats, len(classNames))
+	for _, s := range stats {
+		c := &classes[priorityClass(s.Process, opts)]
+		c.Processes++
+		c.AveWait += float64(s.Wait)
+		c.AveTurnaround += float64(s.Turnaround)
+	}
+
+	used := make([]ClassStats, 0, len(classes))
+	for i, c := range classes {
+		if c.Processes == 0 {
+			continue
+		}
+		c.Class = i
+		c.AveWait /= float64(c.Processes)
+		c.AveTurnaround /= float64(c.Processes)
+		used = append(used, c)
+	}
+
+	return used
+}
+
+func outputClassStats(w io.Writer, classes []ClassStats, opts Options) {
+	_, _ = fmt.Fprintln(w, "Priority classes")
+	scale := float64(opts.ticksPerUnit())
+	table := newTable(w, opts)
+	table.SetHeader([]string{"Class", "Processes", "Average wait", "Average turnaround"})
+	for _, c := range classes {
+		table.Append([]string{
+			classNames[c.Class],
+			fmt.Sprint(c.Processes),
+			formatFloat(c.AveWait/scale, opts.Precision),
+			formatFloat(c.AveTurnaround/scale, opts.Precision),
+		})
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMLQSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6, Priority: 3},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 4, Priority: 2},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 4, Priority: 2},
+		{ProcessID: 4, ArrivalTime: 3, BurstDuration: 2, Priority: 1},
+		{ProcessID: 5, ArrivalTime: 0, BurstDuration: 3, Priority: 4},
+	}
+	result := MLQSchedule("Multi-level queue", processes, DefaultOptions())
+
+	// Batch runs first come first served until the interactive processes arrive, the system process preempts
+	// them, then the interactive processes take quantum turns before batch resumes in arrival order.
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 1},
+		{PID: 2, Start: 1, Stop: 3},
+		{PID: 4, Start: 3, Stop: 5},
+		{PID: 3, Start: 5, Stop: 7},
+		{PID: 2, Start: 7, Stop: 9},
+		{PID: 3, Start: 9, Stop: 11},
+		{PID: 1, Start: 11, Stop: 16},
+		{PID: 5, Start: 16, Stop: 19},
+	}
+	if !reflect.DeepEqual(result.Gantt, want) {
+		t.Errorf("MLQSchedule() gantt = %v, want %v", result.Gantt, want)
+	}
+
+	wantClasses := []ClassStats{
+		{Class: ClassSystem, Processes: 1, AveWait: 0, AveTurnaround: 2},
+		{Class: ClassInteractive, Processes: 2, AveWait: 5, AveTurnaround: 9},
+		{Class: ClassBatch, Processes: 2, AveWait: 13, AveTurnaround: 17.5},
+	}
+	if got := classStats(result.Stats, DefaultOptions()); !reflect.DeepEqual(got, wantClasses) {
+		t.Errorf("classStats() = %+v, want %+v", got, wantClasses)
+	}
+}
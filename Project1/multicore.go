@@ -0,0 +1,337 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// MultiCoreRRSchedule schedules processes round-robin from a single ready queue shared by opts.Cores cores.
+// A process goes back to the core it last ran on when that core is free, otherwise to the lowest free core
+// its affinity allows, paying opts.MigrationCost before its slice starts if the core changed, and
+// opts.DispatchLatency if the core wasn't just running it.
+// A process whose permitted cores are all busy waits, letting processes behind it in the queue run.
+// A process preempted at the end of its quantum goes behind any processes that arrived at the same time.
+// With a positive opts.MaxTime it stops at the first slice ending from then on. If time stops advancing, or
+// processes are left that no core can run, the error is ErrNoProgress naming them.
+func MultiCoreRRSchedule(title string, inputProcesses []Process, opts Options) SchedulerResult {
+	cores := opts.Cores
+	if cores < 1 {
+		cores = 1
+	}
+
+	type task struct {
+		Process
+		remaining int64
+		lastCore  int
+	}
+	type core struct {
+		running *task
+		start   int64
+		stop    int64
+	}
+
+	pending := make([]*task, len(inputProcesses))
+	for i := range inputProcesses {
+		pending[i] = &task{Process: inputProcesses[i], remaining: inputProcesses[i].BurstDuration, lastCore: -1}
+	}
+	sort.SliceStable(pending, func(a, b int) bool {
+		if pending[a].ArrivalTime == pending[b].ArrivalTime {
+			return opts.before(pending[a].Process, pending[b].Process, false)
+		}
+		return pending[a].ArrivalTime < pending[b].ArrivalTime
+	})
+
+	var (
+		time  int64
+		ready = make([]*task, 0)
+		cpus  = make([]core, cores)
+		gantt = make([]TimeSlice, 0)
+	)
+	addSlice := func(c int, t *task, start, stop int64) {
+		// Merge with the core's previous slice if the same process just kept running.
+		for i := len(gantt) - 1; i >= 0; i-- {
+			if gantt[i].Core != c {
+				continue
+			}
+			if gantt[i].PID == t.ProcessID && gantt[i].Stop == start {
+				gantt[i].Stop = stop
+				return
+			}
+			break
+		}
+		gantt = append(gantt, TimeSlice{PID: t.ProcessID, Start: start, Stop: stop, Core: c})
+	}
+
+	stuck := func() SchedulerResult {
+		pids := make([]int64, 0, len(ready)+len(pending)+len(cpus))
+		for _, c := range cpus {
+			if c.running != nil {
+				pids = append(pids, c.running.ProcessID)
+			}
+		}
+		for _, t := range append(append([]*task{}, ready...), pending...) {
+			pids = append(pids, t.ProcessID)
+		}
+		result := calculateCompletionStats(title, cpuBound(inputProcesses), gantt)
+		result.Cores, result.Err = cores, noProgress(pids, time)
+		return result
+	}
+	watchdog := newWatchdog()
+	for {
+		if err := opts.cancelled(); err != nil {
+			result := calculateCompletionStats(title, cpuBound(inputProcesses), gantt)
+			result.Cores, result.Err = cores, err
+			return result
+		}
+		running := 0
+		for c := range cpus {
+			if cpus[c].running != nil {
+				running++
+			}
+		}
+		if watchdog.stuck(time, len(pending)+len(ready)+running) {
+			return stuck()
+		}
+
+		// Finish any slices ending now.
+		preempted := make([]*task, 0)
+		for c := range cpus {
+			if cpus[c].running == nil || cpus[c].stop != time {
+				continue
+			}
+			t := cpus[c].running
+			addSlice(c, t, cpus[c].start, cpus[c].stop)
+			t.remaining -= cpus[c].stop - cpus[c].start
+			if t.remaining > 0 {
+				preempted = append(preempted, t)
+			}
+			cpus[c].running = nil
+		}
+		// At the -max-time cap the slices still running are recorded as they would have run, to be cut off.
+		if until := opts.until(); until >= 0 && time >= until {
+			for c := range cpus {
+				if cpus[c].running != nil {
+					addSlice(c, cpus[c].running, cpus[c].start, cpus[c].stop)
+				}
+			}
+			break
+		}
+
+		// New arrivals queue before the processes that were just preempted.
+		for len(pending) > 0 && pending[0].ArrivalTime <= time {
+			ready = append(ready, pending[0])
+			pending = pending[1:]
+		}
+		ready = append(ready, preempted...)
+
+		// Fill free cores.
+		for i := 0; i < len(ready); {
+			t := ready[i]
+			c := -1
+			if t.lastCore >= 0 && cpus[t.lastCore].running == nil {
+				c = t.lastCore
+			} else {
+				for j := range cpus {
+					if cpus[j].running == nil && canRunOn(t.Process, j) {
+						c = j
+						break
+					}
+				}
+			}
+			if c < 0 {
+				i++
+				continue
+			}
+			ready = append(ready[:i], ready[i+1:]...)
+
+			start := time
+			if t.lastCore >= 0 && t.lastCore != c {
+				start += opts.MigrationCost
+			}
+			if !continuesOn(gantt, c, t.ProcessID, time) {
+				start += opts.DispatchLatency
+			}
+			slice := opts.quantum()
+			if t.remaining < slice {
+				slice = t.remaining
+			}
+			cpus[c] = core{running: t, start: start, stop: start + slice}
+			t.lastCore = c
+		}
+
+		// Advance to the next slice end, or the next arrival if a core would otherwise sit idle.
+		next, idle := int64(-1), false
+		for c := range cpus {
+			if cpus[c].running == nil {
+				idle = true
+			} else if next < 0 || cpus[c].stop < next {
+				next = cpus[c].stop
+			}
+		}
+		if idle && len(pending) > 0 && (next < 0 || pending[0].ArrivalTime < next) {
+			next = pending[0].ArrivalTime
+		}
+		if next < 0 {
+			if len(ready) > 0 {
+				return stuck()
+			}
+			break
+		}
+		time = next
+	}
+
+	result := calculateCompletionStats(title, cpuBound(inputProcesses), gantt)
+	result.Cores = cores
+
+	return result
+}
+
+// continuesOn reports whether the core's last slice in the gantt was the process's, ending at time.
+func continuesOn(gantt []TimeSlice, core int, pid, time int64) bool {
+	for i := len(gantt) - 1; i >= 0; i-- {
+		if gantt[i].Core == core {
+			return gantt[i].PID == pid && gantt[i].Stop == time
+		}
+	}
+
+	return false
+}
+
+// canRunOn reports if the process's affinity permits it to run on the core.
+func canRunOn(p Process, core int) bool {
+	return p.Affinity == 0 || p.Affinity&(1<<core) != 0
+}
+
+// checkAffinity makes sure every process can run on at least one of the cores.
+func checkAffinity(processes []Process, cores int) error {
+	for i := range processes {
+		if processes[i].Affinity < 0 {
+			return fmt.Errorf("%w: process %v has a negative affinity", ErrInvalidArgs, processes[i].ProcessID)
+		}
+		permitted := false
+		for c := 0; c < cores && !permitted; c++ {
+			permitted = canRunOn(processes[i], c)
+		}
+		if !permitted {
+			return fmt.Errorf("%w: process %v has affinity %b but only %v cores",
+				ErrInvalidArgs, processes[i].ProcessID, processes[i].Affinity, cores)
+		}
+	}
+
+	return nil
+}
+
+// calculateCompletionStats derives per-process timing from each process's last slice in the gantt, so it holds when
+// slices overlap on multiple cores or processes block for I/O. It's the one definition of waiting every scheduler
+// shares: the time a process was ready but not running, so neither before it arrived nor while it did I/O. Running
+// is taken from the gantt rather than the burst, as DVFS stretches a burst at lower frequencies.
+func calculateCompletionStats(title string, processes []Process, gantt []TimeSlice) SchedulerResult {
+	var (
+		totalWait       float64
+		totalTurnaround float64
+		lastCompletion  float64
+		stats           = make([]ProcessStats, len(processes))
+	)
+	for i := range processes {
+		var completion, ran int64
+		for j := range gantt {
+			if gantt[j].PID != processes[i].ProcessID {
+				continue
+			}
+			ran += gantt[j].Stop - gantt[j].Start
+			if gantt[j].Stop > completion {
+				completion = gantt[j].Stop
+			}
+		}
+		turnaround := completion - processes[i].ArrivalTime
+		waitingTime := turnaround - ran - processes[i].ioTime()
+		stats[i] = ProcessStats{
+			Process:    processes[i],
+			Wait:       waitingTime,
+			Turnaround: turnaround,
+			Completion: completion,
+		}
+		totalWait += float64(waitingTime)
+		totalTurnaround += float64(turnaround)
+		if float64(completion) > lastCompletion {
+			lastCompletion = float64(completion)
+		}
+	}
+
+	count := float64(len(processes))
+
+	return SchedulerResult{
+		Title:         title,
+		Gantt:         gantt,
+		Stats:         stats,
+		AveWait:       totalWait / count,
+		AveTurnaround: totalTurnaround / count,
+		Throughput:    count / lastCompletion,
+	}
+}
+
+// coreGantt returns the slices of the gantt that ran on a single core.
+func coreGantt(gantt []TimeSlice, core int) []TimeSlice {
+	slices := make([]TimeSlice, 0)
+	for i := range gantt {
+		if gantt[i].Core == core {
+			slices = append(slices, gantt[i])
+		}
+	}
+
+	return slices
+}
+
+// CoreUsage is how much of the makespan a single core spent running processes.
+type CoreUsage struct {
+	Core        int
+	Busy        int64
+	Idle        int64
+	Utilization float64
+}
+
+// coreUtilization breaks down busy and idle time per core over the makespan of the gantt,
+// returning the per-core usage and the aggregate utilization across all cores.
+func coreUtilization(gantt []TimeSlice, cores int) ([]CoreUsage, float64) {
+	var makespan, totalBusy int64
+	usage := make([]CoreUsage, cores)
+	for c := range usage {
+		usage[c].Core = c
+	}
+	for i := range gantt {
+		if gantt[i].Core < 0 || gantt[i].Core >= cores {
+			continue
+		}
+		usage[gantt[i].Core].Busy += gantt[i].Stop - gantt[i].Start
+		if gantt[i].Stop > makespan {
+			makespan = gantt[i].Stop
+		}
+	}
+	if makespan == 0 {
+		return usage, 0
+	}
+	for c := range usage {
+		usage[c].Idle = makespan - usage[c].Busy
+		usage[c].Utilization = 100 * float64(usage[c].Busy) / float64(makespan)
+		totalBusy += usage[c].Busy
+	}
+
+	return usage, 100 * float64(totalBusy) / float64(makespan*int64(cores))
+}
+
+func outputCoreUtilization(w io.Writer, usage []CoreUsage, aggregate float64, opts Options) {
+	_, _ = fmt.Fprintln(w, "Core utilization")
+	table := newTable(w, opts)
+	table.SetHeader([]string{"Core", "Busy", "Idle", "Utilization"})
+	for _, u := range usage {
+		table.Append([]string{
+			fmt.Sprint(u.Core),
+			formatTime(u.Busy, opts.TimeScale),
+			formatTime(u.Idle, opts.TimeScale),
+			formatFloat(u.Utilization, opts.Precision) + "%",
+		})
+	}
+	table.SetFooter([]string{"", "", "Aggregate", formatFloat(aggregate, opts.Precision) + "%"})
+	table.Render()
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMultiCoreRRSchedule(t *testing.T) {
+	t.Parallel()
+	opts := DefaultOptions()
+	opts.Cores = 2
+
+	// The long job keeps core 0 busy while core 1 finishes the short job and idles.
+	got := MultiCoreRRSchedule("Round-robin", []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 10},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+	}, opts)
+
+	wantGantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 10, Core: 0},
+		{PID: 2, Start: 0, Stop: 2, Core: 1},
+	}
+	if !reflect.DeepEqual(got.Gantt, wantGantt) {
+		t.Fatalf("MultiCoreRRSchedule() gantt = %v, want %v", got.Gantt, wantGantt)
+	}
+
+	usage, aggregate := coreUtilization(got.Gantt, got.Cores)
+	wantUsage := []CoreUsage{
+		{Core: 0, Busy: 10, Idle: 0, Utilization: 100},
+		{Core: 1, Busy: 2, Idle: 8, Utilization: 20},
+	}
+	if !reflect.DeepEqual(usage, wantUsage) {
+		t.Errorf("coreUtilization() usage = %v, want %v", usage, wantUsage)
+	}
+	if math.Abs(aggregate-60) > 1e-9 {
+		t.Errorf("coreUtilization() aggregate = %v, want 60", aggregate)
+	}
+
+	var w bytes.Buffer
+	outputResult(&w, got, opts)
+	for _, want := range []string{"Core utilization", "100.00%", "20.00%", "60.00%"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputResult() missing %v: %v", want, w.String())
+		}
+	}
+}
+
+func Test_calculateCompletionStats(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 4},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2},
+	}
+	got := calculateCompletionStats("", processes, []TimeSlice{
+		{PID: 1, Start: 0, Stop: 4, Core: 0},
+		{PID: 2, Start: 1, Stop: 5, Core: 1},
+		{PID: 3, Start: 4, Stop: 6, Core: 0},
+	})
+
+	want := []ProcessStats{
+		{Process: processes[0], Wait: 0, Turnaround: 4, Completion: 4},
+		{Process: processes[1], Wait: 0, Turnaround: 4, Completion: 5},
+		{Process: processes[2], Wait: 3, Turnaround: 5, Completion: 6},
+	}
+	if !reflect.DeepEqual(got.Stats, want) {
+		t.Errorf("calculateCompletionStats() = %v, want %v", got.Stats, want)
+	}
+}
+
+func TestMultiCoreRRSchedule_migrationCost(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		processes      []Process
+		wantGantt      []TimeSlice
+		wantCompletion []int64
+	}{
+		{
+			name: "processes that stay on their core pay nothing",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 6, Core: 0},
+				{PID: 2, Start: 0, Stop: 4, Core: 1},
+			},
+			wantCompletion: []int64{6, 4},
+		},
+		{
+			name: "migrating process pays the cost before its slice",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 10},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: 3, ArrivalTime: 1, BurstDuration: 3},
+			},
+			// Process 3 takes core 0 at time 2, so process 1 moves to core 1 and starts a unit late.
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2, Core: 0},
+				{PID: 2, Start: 0, Stop: 2, Core: 1},
+				{PID: 3, Start: 2, Stop: 5, Core: 0},
+				{PID: 1, Start: 3, Stop: 11, Core: 1},
+			},
+			wantCompletion: []int64{11, 2, 5},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opts := DefaultOptions()
+			opts.Cores = 2
+			opts.MigrationCost = 1
+
+			got := MultiCoreRRSchedule("Round-robin", tt.processes, opts)
+			if !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("MultiCoreRRSchedule() gantt = %v, want %v", got.Gantt, tt.wantGantt)
+			}
+			for i := range got.Stats {
+				if got.Stats[i].Completion != tt.wantCompletion[i] {
+					t.Errorf("MultiCoreRRSchedule() process %v completion = %v, want %v",
+						got.Stats[i].ProcessID, got.Stats[i].Completion, tt.wantCompletion[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMultiCoreRRSchedule_affinity(t *testing.T) {
+	t.Parallel()
+	opts := DefaultOptions()
+	opts.Cores = 2
+
+	// Processes 1 and 2 are pinned to core 0 so they take turns there, even though core 1 frees up at time 4.
+	got := MultiCoreRRSchedule("Round-robin", []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Affinity: 0b01},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4, Affinity: 0b01},
+		{ProcessID: 3, ArrivalTime: 0, BurstDuration: 4},
+	}, opts)
+
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2, Core: 0},
+		{PID: 3, Start: 0, Stop: 4, Core: 1},
+		{PID: 2, Start: 2, Stop: 4, Core: 0},
+		{PID: 1, Start: 4, Stop: 6, Core: 0},
+		{PID: 2, Start: 6, Stop: 8, Core: 0},
+	}
+	if !reflect.DeepEqual(got.Gantt, want) {
+		t.Errorf("MultiCoreRRSchedule() gantt = %v, want %v", got.Gantt, want)
+	}
+}
+
+func Test_checkAffinity(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		affinity int64
+		cores    int
+		wantErr  bool
+	}{
+		{name: "any core", affinity: 0, cores: 1},
+		{name: "permitted core", affinity: 0b10, cores: 2},
+		{name: "only cores that don't exist", affinity: 0b100, cores: 2, wantErr: true},
+		{name: "negative", affinity: -1, cores: 2, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := checkAffinity([]Process{{ProcessID: 1, Affinity: tt.affinity}}, tt.cores)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkAffinity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMultiCoreRRSchedule_noProgress(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 2},
+		// Only allowed on core 2, which there isn't.
+		{ProcessID: 2, BurstDuration: 2, Affinity: 4},
+	}
+	opts := DefaultOptions()
+	opts.Cores = 2
+	got := MultiCoreRRSchedule("RR", processes, opts)
+	if !errors.Is(got.Err, ErrNoProgress) {
+		t.Fatalf("MultiCoreRRSchedule() error = %v, want %v", got.Err, ErrNoProgress)
+	}
+	if !strings.Contains(got.Err.Error(), "[2]") {
+		t.Errorf("MultiCoreRRSchedule() error = %v, should name process 2", got.Err)
+	}
+}
+
+func TestMultiCoreRRSchedule_ignoresIO(t *testing.T) {
+	t.Parallel()
+	withIO := []Process{
+		{ProcessID: 1, BurstDuration: 4, IO: []IORequest{{At: 1, Duration: 5}}},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+	}
+	opts := DefaultOptions()
+	opts.Cores = 2
+	got := MultiCoreRRSchedule("RR", withIO, opts)
+	want := MultiCoreRRSchedule("RR", cpuBound(withIO), opts)
+	for i := range got.Stats {
+		if got.Stats[i].Wait < 0 || got.Stats[i].Wait != want.Stats[i].Wait {
+			t.Errorf("MultiCoreRRSchedule() process %v wait = %v, want the CPU-only %v",
+				got.Stats[i].ProcessID, got.Stats[i].Wait, want.Stats[i].Wait)
+		}
+	}
+	if got.AveWait != want.AveWait {
+		t.Errorf("MultiCoreRRSchedule() average wait = %v, want the CPU-only %v", got.AveWait, want.AveWait)
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import "fmt"
+
+// minNice and maxNice bound a nice value, as on Linux, where a lower nice is a bigger share of the CPU.
+const (
+	minNice = -20
+	maxNice = 19
+)
+
+// niceWeights are the weights Linux's CFS gives each nice value from minNice, sched_prio_to_weight. Nice 0 is
+// a weight of 1024, and each step of nice changes the share of the CPU by about 10% against a process at the
+// old nice, a ratio of about 1.25 between weights.
+var niceWeights = [maxNice - minNice + 1]int64{
+	88761, 71755, 56483, 46273, 36291,
+	29154, 23254, 18705, 14949, 11916,
+	9548, 7620, 6100, 4904, 3906,
+	3121, 2501, 1991, 1586, 1277,
+	1024, 820, 655, 526, 423,
+	335, 272, 215, 172, 137,
+	110, 87, 70, 56, 45,
+	36, 29, 23, 18, 15,
+}
+
+// niceWeight is the CFS weight of the nice value, erroring if it's outside minNice to maxNice.
+func niceWeight(nice int64) (int64, error) {
+	if nice < minNice || nice > maxNice {
+		return 0, fmt.Errorf("%w: nice %v is outside %v to %v", ErrInvalidArgs, nice, minNice, maxNice)
+	}
+
+	return niceWeights[nice-minNice], nil
+}
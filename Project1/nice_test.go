@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_niceWeight(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		nice    int64
+		want    int64
+		wantErr bool
+	}{
+		{nice: 0, want: 1024},
+		{nice: -20, want: 88761},
+		{nice: 1, want: 820},
+		{nice: 19, want: 15},
+		{nice: -21, wantErr: true},
+		{nice: 20, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(fmt.Sprint(tt.nice), func(t *testing.T) {
+			t.Parallel()
+			got, err := niceWeight(tt.nice)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("niceWeight(%v) = %v, %v, want %v, error %v", tt.nice, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_loadProcesses_nice(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		csv     string
+		want    []int64
+		wantErr string
+	}{
+		{name: "weights", csv: "1,5,0,0,0,0,,0,0,0,0,0\n2,5,0,0,0,0,,0,0,0,0,5\n", want: []int64{1024, 335}},
+		{name: "no column", csv: "1,5,0,0,0,0,,0,2\n", want: []int64{2}},
+		{name: "negative weight", csv: "1,5,0,0,0,0,,0,-1\n", wantErr: "process 1 has a negative weight -1"},
+		{name: "out of range", csv: "1,5,0,0,0,0,,0,0,0,0,-21\n", wantErr: "nice -21 is outside -20 to 19"},
+		{name: "weight too", csv: "1,5,0,0,0,0,,0,2,0,0,1\n", wantErr: "both a weight and a nice value"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, _, err := loadProcesses(strings.NewReader(tt.csv))
+			if tt.wantErr != "" {
+				if !errors.Is(err, ErrInvalidArgs) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("loadProcesses() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadProcesses() error = %v", err)
+			}
+			for i, p := range got {
+				if p.Weight != tt.want[i] {
+					t.Errorf("loadProcesses() process %v weight = %v, want %v", p.ProcessID, p.Weight, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,612 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Output formats.
+const (
+	FormatText  = "text"
+	FormatLatex = "latex"
+	// FormatJSON writes each result as a line of JSON, see ResultRecord.
+	FormatJSON = "json"
+)
+
+// Tie-break strategies, for when a scheduler's own rule can't choose between processes.
+const (
+	// TieBreakArrival prefers the earlier arrival, then the lower PID.
+	TieBreakArrival = "arrival"
+	// TieBreakPID prefers the lower PID.
+	TieBreakPID = "pid"
+	// TieBreakFIFO prefers the process queued first.
+	TieBreakFIFO = "fifo"
+)
+
+// Priority orders, how the priority schedulers rank priorities.
+const (
+	// PriorityAscending is the common convention, a lower number being a higher priority.
+	PriorityAscending = "asc"
+	// PriorityDescending is the inverted convention of some textbooks, a higher number being a higher priority.
+	PriorityDescending = "desc"
+)
+
+// SJF tie policies, for when several ready processes share the shortest burst.
+const (
+	// SJFFirstFit picks the earliest arrival, then the lower PID, as TieBreakArrival does.
+	SJFFirstFit = "first-fit"
+	// SJFBestFit picks the lower PID, as TieBreakPID does.
+	SJFBestFit = "best-fit"
+)
+
+// Options configures how processes are scheduled and how the results are output.
+type Options struct {
+	// Format is the output format, one of FormatText, FormatLatex or FormatJSON.
+	Format string
+	// LatexGantt adds a tikz gantt chart to the latex output.
+	LatexGantt bool
+	// Cores is the number of cores to schedule on, anything above one uses the multi-core scheduler.
+	Cores int
+	// MigrationCost is the time lost when a process resumes on a different core than it last ran on.
+	MigrationCost int64
+	// DispatchLatency is the time from choosing a process to it running, paid by the schedulers on every
+	// dispatch, unlike MigrationCost only when a process changes core.
+	DispatchLatency int64
+	// Algorithms are the names of the schedulers to run in order, empty runs the defaults.
+	Algorithms []string
+	// StarvationWait warns about processes waiting longer than it, zero turns the warning off.
+	StarvationWait int64
+	// StarvationFactor warns about processes waiting longer than this multiple of their burst, zero turns the warning off.
+	StarvationFactor float64
+	// EventLog is a file to write every scheduling event to as JSON Lines, empty for none. The events are derived
+	// from each result's gantt, see scheduleEvents.
+	EventLog string
+	// Precision is the number of decimal places floating point results are output with.
+	Precision int
+	// MaxWait is the longest the bounded round-robin scheduler lets a process wait between turns.
+	MaxWait int64
+	// GanttAxis adds a gantt chart drawn to scale with a legend and time axis under the plain one.
+	GanttAxis bool
+	// GanttTicks is the interval between the time axis' tick marks, zero adapts it to the makespan.
+	GanttTicks int64
+	// Width wraps the plain gantt into bands no wider than it, zero never wraps.
+	Width int
+	// widthGiven is whether -width was given, otherwise main sets Width from the terminal, see terminalWidth.
+	widthGiven bool
+	// GanttScale is the columns per unit of time of the gantt drawn to scale, zero fits it to ganttWidth.
+	GanttScale float64
+	// ThroughputWindow reports the completions in every window of this length, zero turns it off.
+	ThroughputWindow int64
+	// Monochrome fills each process's gantt bars with its own symbol, with a legend, so they're distinct without color.
+	Monochrome bool
+	// Response reports average response time beside average waiting time.
+	Response bool
+	// CompletionOrder lists the processes in the order they finished.
+	CompletionOrder bool
+	// QueueLength reports the peak length of the ready queue.
+	QueueLength bool
+	// WeightedTurnaround reports the burst-weighted average turnaround beside the plain average.
+	WeightedTurnaround bool
+	// Warmup ignores processes completing before it in the steady-state metrics.
+	Warmup int64
+	// Window ignores processes completing after it in the steady-state metrics, zero runs to the last completion.
+	Window int64
+	// BestEffort skips malformed rows of the input file, reporting them, rather than failing on the first.
+	BestEffort bool
+	// DryRun only validates and summarizes the workload, without scheduling it.
+	DryRun bool
+	// TUI animates each schedule in the terminal, falling back to the plain output when stdout isn't one.
+	// It can't be combined with PinOutputOrder.
+	TUI bool
+	// CompareFairness follows the schedules with a comparison of how fairly each shared the CPU.
+	CompareFairness bool
+	// Explain follows each schedule with the reason for every dispatch.
+	Explain bool
+	// InversionDemo runs the priority inversion scenario instead of scheduling a file.
+	InversionDemo bool
+	// ProducerConsumer runs the bounded buffer producer/consumer scenario instead of scheduling a file.
+	ProducerConsumer bool
+	// BankersDemo runs the two resource deadlock scenario, with and without the Banker's algorithm, instead of
+	// scheduling a file.
+	BankersDemo bool
+	// BufferSize is the capacity of the producer/consumer scenario's buffer.
+	BufferSize int64
+	// MaxTime stops the simulation when the clock reaches it, reporting the processes still unfinished; zero doesn't.
+	MaxTime int64
+	// ctx abandons the schedulers once it's done, see WithContext.
+	ctx context.Context
+	// InputFormat is the format of the scheduling file, one of inputFormats.
+	InputFormat string
+	// NoSafetySort schedules processes in file order rather than sorting them by arrival first.
+	// Schedulers that assume arrival order, such as FCFS, then follow the file even when it's out of order.
+	NoSafetySort bool
+	// Frequencies are the CPU frequency levels the dvfs scheduler can choose from, relative to full speed.
+	Frequencies []float64
+	// DVFSPolicy is how the dvfs scheduler chooses its frequency, one of dvfsPolicies.
+	DVFSPolicy string
+	// ValidateAgainst is a file of results written by -format json to compare this run's results with.
+	ValidateAgainst string
+	// Tolerance is how far -validate-against lets averages and throughput differ from the reference.
+	Tolerance float64
+	// LoadSweep runs the schedulers on Poisson workloads at each of its offered loads instead of scheduling a file,
+	// zero for no sweep.
+	LoadSweep LoadSweep
+	// Alpha weighs the spn scheduler's exponential average towards a process's latest burst, from 0 to 1.
+	Alpha float64
+	// InitialPrediction is the spn scheduler's prediction of a process's first burst.
+	InitialPrediction int64
+	// Plain renders tables as tab separated columns rather than boxes.
+	Plain bool
+	// GanttCSV is a file to export every schedule's gantt to as CSV, empty for none.
+	GanttCSV string
+	// SJFTie is how SJF chooses between equal bursts, SJFFirstFit or SJFBestFit, overriding TieBreak for SJF.
+	// Empty leaves SJF to TieBreak like every other scheduler, which is first-fit by default.
+	SJFTie string
+	// Columns are the names of the CSV file's columns in its order, nil for the default order of csvColumns.
+	Columns []string
+	// PinOutputOrder runs the schedulers concurrently, buffering each one's output and printing them in the
+	// order they were selected once every scheduler is done.
+	PinOutputOrder bool
+	// BurstBucket is the width of the -dry-run burst histogram's buckets.
+	BurstBucket int64
+	// PriorityOrder is whether a lower priority number is a higher priority, PriorityAscending, or a higher one is,
+	// PriorityDescending.
+	PriorityOrder string
+	// MetricsOnly outputs only the aggregate metrics of every schedule, as a single JSON object.
+	MetricsOnly bool
+	// AgingInterval is how long a process waits for each level priority-aging ages its priority by, zero for none.
+	AgingInterval int64
+	// ShowAging lists each process's effective priority over time, for schedulers that age priorities.
+	ShowAging bool
+	// Theme is the style of the boxed tables, one of themes.
+	Theme string
+	// AssertSchedulable only checks that the earliest-deadline-first schedule meets every deadline,
+	// exiting non-zero if it doesn't.
+	AssertSchedulable bool
+	// GanttJustify is how the text gantt justifies PIDs in their cells, JustifyCenter, JustifyLeft or JustifyRight.
+	GanttJustify string
+	// GanttMergeIdle draws each idle gap in the gantt as one slice, otherwise it's split wherever something else
+	// in the schedule started or stopped during it.
+	GanttMergeIdle bool
+	// GroupBy groups the schedule table by one of groupFields, with a subtotal per group, empty for no grouping.
+	GroupBy string
+	// CompactGantt draws each gantt as a single line of slices, like "|1:0-5|idle:5-7|2:7-12|", instead of a chart.
+	CompactGantt bool
+	// Limit schedules only the first Limit processes of the file, zero for them all.
+	Limit int
+	// RelativeTimes reports every time relative to the first arrival, shifting the arrivals and deadlines down
+	// before scheduling so it's at zero.
+	RelativeTimes bool
+	// WorkloadStats prints lower bounds on the makespan and average wait before scheduling the workload.
+	WorkloadStats bool
+	// AbortAtDeadline has EDF abort a process still running at its deadline rather than run it to completion.
+	AbortAtDeadline bool
+	// WeightedFair gives each process a round-robin quantum of the time quantum times its weight, keeping the
+	// round order.
+	WeightedFair bool
+	// RepeatableHash prints a hash of each result, the same for every run with the same input and options.
+	RepeatableHash bool
+	// ComparePreemption compares the preemptive and non-preemptive variants of each base policy instead of
+	// running the schedulers.
+	ComparePreemption bool
+	// WaitTimeline are the processes whose intervals of waiting and running follow each schedule.
+	WaitTimeline []int64
+	// ExportProcesses writes the loaded processes back out in this input format, InputCSV or InputJSON,
+	// instead of scheduling them. Empty schedules them.
+	ExportProcesses string
+	// Profile is a file to write a pprof CPU profile of the scheduling to, empty for none.
+	Profile string
+	// UtilityDecay is how long after its deadline a process's value takes to decay to nothing, zero for a hard
+	// deadline, see utility.
+	UtilityDecay int64
+	// InspectAt are the times to show what the one scheduler of Algorithms was running and had ready at,
+	// instead of its output.
+	InspectAt []int64
+	// Iterations benchmarks the schedulers on this many random workloads instead of scheduling a file.
+	Iterations int
+	// REPL builds and schedules workloads interactively instead of scheduling a file.
+	REPL bool
+	// Jitter moves each arrival in the file by a random amount of at most this either way, seeded by Seed.
+	Jitter int64
+	// Seed seeds the random workloads and Jitter.
+	Seed int64
+	// RMCheck checks the periodic tasks against the rate-monotonic utilization bound before scheduling.
+	RMCheck bool
+	// SummaryOnly outputs only each schedule's averages, without its gantt, table or other metrics.
+	SummaryOnly bool
+	// PreserveOrder outputs the schedule table in the input file's order rather than the scheduler's.
+	PreserveOrder bool
+	// Snapshot is a file to save the simulation to at SnapshotAt instead of finishing it, empty for none.
+	Snapshot   string
+	SnapshotAt int64
+	// Resume is a snapshot file to finish the simulation of instead of scheduling a file, empty for none.
+	Resume string
+	// TieBreak is the strategy for ties, one of TieBreakArrival, TieBreakPID or TieBreakFIFO.
+	TieBreak string
+	// TimeScale is the ticks per unit of time processes were loaded with, see timeScale.
+	TimeScale int64
+}
+
+// DefaultOptions are the options used when no flags are given.
+func DefaultOptions() Options {
+	return Options{
+		Format:            FormatText,
+		Cores:             1,
+		Precision:         2,
+		TimeScale:         1,
+		Seed:              1,
+		BufferSize:        2,
+		InputFormat:       InputAuto,
+		Frequencies:       []float64{1, 0.5},
+		DVFSPolicy:        DVFSOnDemand,
+		Tolerance:         0.01,
+		Alpha:             0.5,
+		InitialPrediction: 10,
+		PriorityOrder:     PriorityAscending,
+		AgingInterval:     defaultAgingInterval,
+		Theme:             ThemeDefault,
+		GanttJustify:      JustifyCenter,
+		GanttMergeIdle:    true,
+		UtilityDecay:      10,
+		BurstBucket:       defaultBurstBucket,
+		TieBreak:          TieBreakArrival,
+	}
+}
+
+// parseOptions parses the CLI flags, returning the options and the remaining args with the binary name kept first.
+func parseOptions(args ...string) (Options, []string, error) {
+	opts := DefaultOptions()
+	if len(args) == 0 {
+		return opts, args, nil
+	}
+
+	fs := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	fs.StringVar(&opts.Format, "format", opts.Format, "output format: text, latex or json")
+	fs.BoolVar(&opts.LatexGantt, "latex-gantt", opts.LatexGantt, "include a tikz gantt chart in latex output")
+	fs.IntVar(&opts.Cores, "cores", opts.Cores, "number of cores to schedule on")
+	fs.Int64Var(&opts.MigrationCost, "migration-cost", opts.MigrationCost, "time penalty when a process moves between cores")
+	fs.Int64Var(&opts.StarvationWait, "starvation-wait", opts.StarvationWait, "warn when a process waits longer than this")
+	fs.Float64Var(&opts.StarvationFactor, "starvation-factor", opts.StarvationFactor, "warn when a process waits longer than this multiple of its burst")
+	fs.StringVar(&opts.EventLog, "eventlog", opts.EventLog, "file to write scheduling events to as JSON Lines")
+	fs.IntVar(&opts.Precision, "precision", opts.Precision, "decimal places for averages and other floating point results")
+	fs.Int64Var(&opts.MaxWait, "max-wait", opts.MaxWait, "longest a process may wait between turns with rr-bounded")
+	fs.BoolVar(&opts.GanttAxis, "gantt-axis", opts.GanttAxis, "draw the gantt to scale with a legend and time axis")
+	fs.Int64Var(&opts.GanttTicks, "gantt-ticks", opts.GanttTicks, "interval between the gantt axis' tick marks, implies -gantt-axis")
+	fs.IntVar(&opts.Width, "width", opts.Width, "wrap the gantt to this many columns, 0 to never wrap (default $COLUMNS in a terminal, otherwise 80)")
+	fs.Float64Var(&opts.GanttScale, "gantt-scale", opts.GanttScale, "columns per unit of time of the gantt axis, implies -gantt-axis")
+	fs.Int64Var(&opts.ThroughputWindow, "throughput-window", opts.ThroughputWindow, "report completions in every window of this length")
+	fs.BoolVar(&opts.Monochrome, "mono", opts.Monochrome, "fill gantt bars with a symbol per process")
+	fs.BoolVar(&opts.Response, "response", opts.Response, "report average response time beside average waiting time")
+	fs.BoolVar(&opts.CompletionOrder, "completion-order", opts.CompletionOrder, "list the processes in the order they finished")
+	fs.BoolVar(&opts.QueueLength, "queue-length", opts.QueueLength, "report the peak length of the ready queue")
+	fs.BoolVar(&opts.WeightedTurnaround, "weighted", opts.WeightedTurnaround, "report the burst-weighted average turnaround")
+	fs.Int64Var(&opts.Warmup, "warmup", opts.Warmup, "ignore processes completing before this in the steady-state metrics")
+	fs.Int64Var(&opts.Window, "window", opts.Window, "ignore processes completing after this in the steady-state metrics")
+	fs.BoolVar(&opts.BestEffort, "best-effort", opts.BestEffort, "skip malformed rows of the input file instead of failing")
+	fs.BoolVar(&opts.DryRun, "dry-run", opts.DryRun, "only validate and summarize the input file")
+	fs.BoolVar(&opts.DryRun, "validate", opts.DryRun, "alias for -dry-run")
+	fs.BoolVar(&opts.TUI, "tui", opts.TUI, "animate each schedule in the terminal")
+	fs.BoolVar(&opts.CompareFairness, "compare-fairness", opts.CompareFairness, "compare the schedulers' fairness after their output")
+	fs.BoolVar(&opts.Explain, "explain", opts.Explain, "explain why each process was dispatched")
+	fs.BoolVar(&opts.InversionDemo, "inversion-demo", opts.InversionDemo, "demonstrate priority inversion and inheritance")
+	fs.BoolVar(&opts.ProducerConsumer, "producer-consumer", opts.ProducerConsumer, "simulate producers and consumers sharing a bounded buffer")
+	fs.BoolVar(&opts.BankersDemo, "bankers-demo", opts.BankersDemo, "demonstrate deadlock avoidance with the Banker's algorithm")
+	fs.Int64Var(&opts.BufferSize, "buffer", opts.BufferSize, "capacity of the -producer-consumer buffer")
+	fs.Int64Var(&opts.MaxTime, "max-time", opts.MaxTime, "stop the simulation at this time, reporting what's unfinished")
+	fs.StringVar(&opts.InputFormat, "input-format", opts.InputFormat, "format of the scheduling file: "+strings.Join(inputFormats, ", "))
+	fs.BoolVar(&opts.NoSafetySort, "no-safety-sort", opts.NoSafetySort, "schedule processes in file order, not sorted by arrival")
+	fs.IntVar(&opts.Iterations, "iterations", opts.Iterations, "benchmark the schedulers on this many random workloads")
+	fs.IntVar(&opts.Iterations, "repeat", opts.Iterations, "alias for -iterations")
+	fs.BoolVar(&opts.REPL, "repl", opts.REPL, "build and schedule workloads interactively")
+	fs.Int64Var(&opts.Seed, "seed", opts.Seed, "seed for the random workloads and -jitter")
+	fs.Int64Var(&opts.Jitter, "jitter", opts.Jitter, "move each arrival by a random amount of at most this either way")
+	fs.BoolVar(&opts.RMCheck, "rm-check", opts.RMCheck, "check the periodic tasks' rate-monotonic schedulability")
+	fs.BoolVar(&opts.SummaryOnly, "summary-only", opts.SummaryOnly, "output only each schedule's averages")
+	fs.BoolVar(&opts.PreserveOrder, "preserve-order", opts.PreserveOrder, "output the schedule table in the input file's order")
+	fs.StringVar(&opts.Snapshot, "snapshot", opts.Snapshot, "save the simulation to this file at -snapshot-at instead of finishing it, with -algo one of: "+strings.Join(resumableNames(), ", "))
+	fs.Int64Var(&opts.SnapshotAt, "snapshot-at", opts.SnapshotAt, "time to save the -snapshot at")
+	fs.StringVar(&opts.Resume, "resume", opts.Resume, "finish the simulation saved in this snapshot file")
+	fs.StringVar(&opts.TieBreak, "tiebreak", opts.TieBreak, "strategy for ties: arrival, pid or fifo")
+	fs.StringVar(&opts.DVFSPolicy, "dvfs-policy", opts.DVFSPolicy, "how dvfs chooses its frequency: "+strings.Join(dvfsPolicies, ", "))
+	frequencies := fs.String("frequencies", "", "comma separated CPU frequency levels for dvfs, relative to full speed")
+	fs.StringVar(&opts.ValidateAgainst, "validate-against", opts.ValidateAgainst, "compare the results with a file written by -format json, exiting 1 on a difference")
+	fs.Float64Var(&opts.Tolerance, "tolerance", opts.Tolerance, "how far -validate-against lets averages and throughput differ")
+	sweep := fs.String("load-sweep", "", "from:to:step offered loads to run the schedulers at on Poisson workloads")
+	fs.Float64Var(&opts.Alpha, "alpha", opts.Alpha, "weight of the latest burst in spn's burst predictions, from 0 to 1")
+	fs.Int64Var(&opts.InitialPrediction, "tau0", opts.InitialPrediction, "spn's prediction of each process's first burst")
+	fs.BoolVar(&opts.Plain, "plain", opts.Plain, "render tables as tab separated columns, not boxes")
+	fs.StringVar(&opts.GanttCSV, "gantt-csv", opts.GanttCSV, "file to export the gantt charts to as CSV")
+	fs.StringVar(&opts.SJFTie, "sjf-tie", opts.SJFTie, "how sjf chooses between equal bursts: first-fit or best-fit, instead of -tiebreak")
+	columns := fs.String("columns", "", "comma separated order of the CSV columns, from: "+strings.Join(csvColumns, ", "))
+	fs.BoolVar(&opts.PinOutputOrder, "pin-output-order", opts.PinOutputOrder, "run the schedulers concurrently, printing them in the -algo order once all are done")
+	fs.Int64Var(&opts.BurstBucket, "burst-bucket", opts.BurstBucket, "width of the -dry-run burst histogram's buckets")
+	fs.StringVar(&opts.PriorityOrder, "priority-order", opts.PriorityOrder, "asc if a lower priority number is a higher priority, desc if a higher one is")
+	fs.BoolVar(&opts.MetricsOnly, "metrics-only", opts.MetricsOnly, "output only every schedule's aggregate metrics, as a single JSON object")
+	fs.Int64Var(&opts.AgingInterval, "aging-interval", opts.AgingInterval, "how long a process waits for priority-aging to raise its priority a level, 0 for no aging")
+	fs.BoolVar(&opts.ShowAging, "show-aging", opts.ShowAging, "list each process's effective priority over time, for priority-aging")
+	fs.StringVar(&opts.Theme, "theme", opts.Theme, "style of the tables: "+strings.Join(themes, ", "))
+	fs.BoolVar(&opts.AssertSchedulable, "assert-schedulable", opts.AssertSchedulable, "only check that an edf schedule meets every deadline, exiting 1 if it doesn't")
+	ljust := fs.Bool("ljust", false, "left-justify the PIDs in the gantt's cells")
+	rjust := fs.Bool("rjust", false, "right-justify the PIDs in the gantt's cells")
+	fs.BoolVar(&opts.GanttMergeIdle, "gantt-merge-idle", opts.GanttMergeIdle, "draw each idle gap as one gantt slice, =false to split it where other events happened")
+	fs.StringVar(&opts.GroupBy, "group-by", opts.GroupBy, "group the schedule table, with subtotals, by: "+strings.Join(groupFields, ", "))
+	fs.BoolVar(&opts.CompactGantt, "compact-gantt", opts.CompactGantt, `draw each gantt as a single line, like "|1:0-5|idle:5-7|2:7-12|"`)
+	fs.IntVar(&opts.Limit, "limit", opts.Limit, "schedule only the first n processes of the file, 0 for all")
+	fs.BoolVar(&opts.RelativeTimes, "relative-times", opts.RelativeTimes, "report every time relative to the first arrival")
+	fs.Int64Var(&opts.DispatchLatency, "dispatch-latency", opts.DispatchLatency, "time from choosing a process to it running, paid on every dispatch")
+	fs.BoolVar(&opts.WorkloadStats, "workload-stats", opts.WorkloadStats, "print the workload's total work, minimum makespan and optimal average wait before scheduling it")
+	fs.BoolVar(&opts.AbortAtDeadline, "abort-at-deadline", opts.AbortAtDeadline, "have edf abort a process still running at its deadline")
+	fs.BoolVar(&opts.WeightedFair, "weighted-fair", opts.WeightedFair, "give each process a round-robin quantum proportional to its weight")
+	fs.BoolVar(&opts.RepeatableHash, "repeatable-hash", opts.RepeatableHash, "print a hash of each schedule and its metrics, to compare results at a glance")
+	fs.BoolVar(&opts.ComparePreemption, "compare-preemption", opts.ComparePreemption, "compare shortest-job-first and priority with and without preemption")
+	waitTimeline := fs.String("wait-timeline", "", "comma separated IDs of processes to show when each was waiting and running")
+	fs.StringVar(&opts.ExportProcesses, "export-processes", opts.ExportProcesses, "write the loaded processes back out in canonical form, as csv or json, instead of scheduling them")
+	fs.StringVar(&opts.Profile, "profile", opts.Profile, "file to write a pprof CPU profile of the scheduling to")
+	fs.Int64Var(&opts.UtilityDecay, "utility-decay", opts.UtilityDecay, "time after its deadline a process's value takes to decay to nothing, 0 for a hard deadline")
+	inspectAt := fs.String("inspect-at", "", "comma separated times to show the running process and ready queue at, instead of the -algo scheduler's output")
+	algos := fs.String("algo", "", "comma separated schedulers to run, from: "+strings.Join(algorithmNames(), ", "))
+	if err := fs.Parse(args[1:]); err != nil {
+		return opts, nil, fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "width" {
+			opts.widthGiven = true
+		}
+	})
+
+	if *algos != "" {
+		for _, name := range strings.Split(*algos, ",") {
+			name = strings.TrimSpace(name)
+			if _, ok := algorithms[name]; !ok {
+				return opts, nil, fmt.Errorf("%w: unknown algorithm %q", ErrInvalidArgs, name)
+			}
+			opts.Algorithms = append(opts.Algorithms, name)
+		}
+	}
+
+	if *sweep != "" {
+		var err error
+		if opts.LoadSweep, err = parseLoadSweep(*sweep); err != nil {
+			return opts, nil, err
+		}
+	}
+	if *columns != "" {
+		var err error
+		if opts.Columns, err = parseColumns(*columns); err != nil {
+			return opts, nil, err
+		}
+	}
+	if *inspectAt != "" {
+		for _, s := range strings.Split(*inspectAt, ",") {
+			at, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+			if err != nil || at < 0 {
+				return opts, nil, fmt.Errorf("%w: inspection time %q isn't a time", ErrInvalidArgs, s)
+			}
+			opts.InspectAt = append(opts.InspectAt, at)
+		}
+		if len(opts.Algorithms) != 1 {
+			return opts, nil, fmt.Errorf("%w: -inspect-at needs -algo to name one scheduler", ErrInvalidArgs)
+		}
+		if _, ok := inspectable[opts.Algorithms[0]]; !ok {
+			return opts, nil, fmt.Errorf("%w: %q can't be inspected, only %v", ErrInvalidArgs,
+				opts.Algorithms[0], strings.Join(inspectableNames(), ", "))
+		}
+	}
+	if *waitTimeline != "" {
+		for _, s := range strings.Split(*waitTimeline, ",") {
+			pid, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+			if err != nil {
+				return opts, nil, fmt.Errorf("%w: wait timeline process %q isn't an ID", ErrInvalidArgs, s)
+			}
+			opts.WaitTimeline = append(opts.WaitTimeline, pid)
+		}
+	}
+	if *frequencies != "" {
+		opts.Frequencies = nil
+		for _, s := range strings.Split(*frequencies, ",") {
+			f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+			if err != nil || f <= 0 {
+				return opts, nil, fmt.Errorf("%w: frequency %q isn't a positive number", ErrInvalidArgs, s)
+			}
+			opts.Frequencies = append(opts.Frequencies, f)
+		}
+	}
+	switch opts.DVFSPolicy {
+	case DVFSPerformance, DVFSPowersave, DVFSOnDemand:
+	default:
+		return opts, nil, fmt.Errorf("%w: unknown DVFS policy %q", ErrInvalidArgs, opts.DVFSPolicy)
+	}
+
+	switch opts.Format {
+	case FormatText, FormatLatex, FormatJSON:
+	default:
+		return opts, nil, fmt.Errorf("%w: unknown format %q", ErrInvalidArgs, opts.Format)
+	}
+	switch opts.ExportProcesses {
+	case "", InputCSV, InputJSON:
+	default:
+		return opts, nil, fmt.Errorf("%w: unknown export format %q", ErrInvalidArgs, opts.ExportProcesses)
+	}
+	if opts.WorkloadStats && (opts.Format == FormatJSON || opts.MetricsOnly) {
+		return opts, nil, fmt.Errorf("%w: -workload-stats can't be given with JSON output", ErrInvalidArgs)
+	}
+	switch opts.TieBreak {
+	case TieBreakArrival, TieBreakPID, TieBreakFIFO:
+	default:
+		return opts, nil, fmt.Errorf("%w: unknown tie-break %q", ErrInvalidArgs, opts.TieBreak)
+	}
+	switch {
+	case *ljust && *rjust:
+		return opts, nil, fmt.Errorf("%w: -ljust and -rjust can't both be given", ErrInvalidArgs)
+	case *ljust:
+		opts.GanttJustify = JustifyLeft
+	case *rjust:
+		opts.GanttJustify = JustifyRight
+	}
+	switch opts.GroupBy {
+	case "", GroupByPriority, GroupByCore, GroupByBatch:
+	default:
+		return opts, nil, fmt.Errorf("%w: unknown -group-by field %q", ErrInvalidArgs, opts.GroupBy)
+	}
+	switch opts.Theme {
+	case ThemeDefault, ThemeMarkdown, ThemeBordered, ThemeCompact:
+	default:
+		return opts, nil, fmt.Errorf("%w: unknown theme %q", ErrInvalidArgs, opts.Theme)
+	}
+	switch opts.PriorityOrder {
+	case PriorityAscending, PriorityDescending:
+	default:
+		return opts, nil, fmt.Errorf("%w: unknown priority order %q", ErrInvalidArgs, opts.PriorityOrder)
+	}
+	switch opts.SJFTie {
+	case "", SJFFirstFit, SJFBestFit:
+	default:
+		return opts, nil, fmt.Errorf("%w: unknown SJF tie policy %q", ErrInvalidArgs, opts.SJFTie)
+	}
+	switch opts.InputFormat {
+	case InputAuto, InputCSV, InputJSON:
+	default:
+		return opts, nil, fmt.Errorf("%w: unknown input format %q", ErrInvalidArgs, opts.InputFormat)
+	}
+	if opts.StarvationWait < 0 || opts.StarvationFactor < 0 {
+		return opts, nil, fmt.Errorf("%w: starvation thresholds can't be negative", ErrInvalidArgs)
+	}
+	if opts.AgingInterval < 0 {
+		return opts, nil, fmt.Errorf("%w: aging interval can't be negative, got %v", ErrInvalidArgs, opts.AgingInterval)
+	}
+	if opts.BurstBucket <= 0 {
+		return opts, nil, fmt.Errorf("%w: burst bucket must be positive, got %v", ErrInvalidArgs, opts.BurstBucket)
+	}
+	if opts.MaxWait < 0 {
+		return opts, nil, fmt.Errorf("%w: max wait can't be negative, got %v", ErrInvalidArgs, opts.MaxWait)
+	}
+	if opts.GanttTicks < 0 {
+		return opts, nil, fmt.Errorf("%w: gantt tick interval can't be negative, got %v", ErrInvalidArgs, opts.GanttTicks)
+	}
+	if opts.Width < 0 {
+		return opts, nil, fmt.Errorf("%w: width can't be negative, got %v", ErrInvalidArgs, opts.Width)
+	}
+	if opts.GanttScale < 0 {
+		return opts, nil, fmt.Errorf("%w: gantt scale can't be negative, got %v", ErrInvalidArgs, opts.GanttScale)
+	}
+	if opts.GanttTicks > 0 || opts.GanttScale > 0 {
+		opts.GanttAxis = true
+	}
+	if opts.ThroughputWindow < 0 {
+		return opts, nil, fmt.Errorf("%w: throughput window can't be negative, got %v", ErrInvalidArgs, opts.ThroughputWindow)
+	}
+	if opts.Warmup < 0 || opts.Window < 0 {
+		return opts, nil, fmt.Errorf("%w: warmup and window can't be negative", ErrInvalidArgs)
+	}
+	if opts.Window > 0 && opts.Window <= opts.Warmup {
+		return opts, nil, fmt.Errorf("%w: window %v must end after the warmup %v", ErrInvalidArgs, opts.Window, opts.Warmup)
+	}
+	if opts.Snapshot != "" {
+		if len(opts.Algorithms) != 1 {
+			return opts, nil, fmt.Errorf("%w: -snapshot needs -algo to name one scheduler", ErrInvalidArgs)
+		}
+		if _, ok := resumable[opts.Algorithms[0]]; !ok {
+			return opts, nil, fmt.Errorf("%w: %q can't be snapshotted, only %v", ErrInvalidArgs,
+				opts.Algorithms[0], strings.Join(resumableNames(), ", "))
+		}
+	}
+	if opts.SnapshotAt < 0 {
+		return opts, nil, fmt.Errorf("%w: snapshot time can't be negative, got %v", ErrInvalidArgs, opts.SnapshotAt)
+	}
+	if opts.BufferSize < 1 {
+		return opts, nil, fmt.Errorf("%w: buffer must hold at least one item, got %v", ErrInvalidArgs, opts.BufferSize)
+	}
+	if opts.Tolerance < 0 {
+		return opts, nil, fmt.Errorf("%w: tolerance can't be negative, got %v", ErrInvalidArgs, opts.Tolerance)
+	}
+	if opts.Alpha < 0 || opts.Alpha > 1 {
+		return opts, nil, fmt.Errorf("%w: alpha must be from 0 to 1, got %v", ErrInvalidArgs, opts.Alpha)
+	}
+	if opts.InitialPrediction < 0 {
+		return opts, nil, fmt.Errorf("%w: initial prediction can't be negative, got %v", ErrInvalidArgs, opts.InitialPrediction)
+	}
+	if opts.Limit < 0 {
+		return opts, nil, fmt.Errorf("%w: limit can't be negative, got %v", ErrInvalidArgs, opts.Limit)
+	}
+	if opts.Jitter < 0 {
+		return opts, nil, fmt.Errorf("%w: jitter can't be negative, got %v", ErrInvalidArgs, opts.Jitter)
+	}
+	if opts.MaxTime < 0 {
+		return opts, nil, fmt.Errorf("%w: max time can't be negative, got %v", ErrInvalidArgs, opts.MaxTime)
+	}
+	if opts.Iterations < 0 {
+		return opts, nil, fmt.Errorf("%w: iterations can't be negative, got %v", ErrInvalidArgs, opts.Iterations)
+	}
+	if opts.Precision < 0 {
+		return opts, nil, fmt.Errorf("%w: precision can't be negative, got %v", ErrInvalidArgs, opts.Precision)
+	}
+	if opts.TUI && opts.PinOutputOrder {
+		return opts, nil, fmt.Errorf("%w: -tui can't be given with -pin-output-order, which buffers the output", ErrInvalidArgs)
+	}
+	if opts.UtilityDecay < 0 {
+		return opts, nil, fmt.Errorf("%w: utility decay can't be negative, got %v", ErrInvalidArgs, opts.UtilityDecay)
+	}
+	if opts.DispatchLatency < 0 {
+		return opts, nil, fmt.Errorf("%w: dispatch latency can't be negative, got %v", ErrInvalidArgs, opts.DispatchLatency)
+	}
+	if opts.MigrationCost < 0 {
+		return opts, nil, fmt.Errorf("%w: migration cost can't be negative, got %v", ErrInvalidArgs, opts.MigrationCost)
+	}
+	if opts.Cores < 1 {
+		return opts, nil, fmt.Errorf("%w: need at least one core, got %v", ErrInvalidArgs, opts.Cores)
+	}
+
+	return opts, append([]string{args[0]}, fs.Args()...), nil
+}
+
+// before is the tie-break comparison every scheduler uses, reporting whether a wins a tie with b.
+// aFirst is whether a was queued before b, sorts passing false so a stable sort keeps the queue's order.
+func (o Options) before(a, b Process, aFirst bool) bool {
+	switch o.TieBreak {
+	case TieBreakPID:
+		return a.ProcessID < b.ProcessID
+	case TieBreakFIFO:
+		return aFirst
+	default:
+		if a.ArrivalTime != b.ArrivalTime {
+			return a.ArrivalTime < b.ArrivalTime
+		}
+		return a.ProcessID < b.ProcessID
+	}
+}
+
+// higherPriority reports if priority a is higher than priority b in the priority order.
+func (o Options) higherPriority(a, b int64) bool {
+	if o.PriorityOrder == PriorityDescending {
+		return a > b
+	}
+
+	return a < b
+}
+
+// sjfTieBreak is the options with the tie-break SJF uses between equal bursts, from SJFTie if it's set.
+func (o Options) sjfTieBreak() Options {
+	switch o.SJFTie {
+	case SJFFirstFit:
+		o.TieBreak = TieBreakArrival
+	case SJFBestFit:
+		o.TieBreak = TieBreakPID
+	}
+
+	return o
+}
+
+// algorithmNames lists the names -algo accepts, sorted.
+func algorithmNames() []string {
+	names := make([]string, 0, len(algorithms))
+	for name := range algorithms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,468 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseOptions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		args     []string
+		want     func(*Options)
+		wantArgs []string
+		wantErr  bool
+	}{
+		{
+			name:     "defaults",
+			args:     []string{"binary_name", "file.csv"},
+			want:     func(*Options) {},
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name: "latex",
+			args: []string{"binary_name", "-format", "latex", "-latex-gantt", "file.csv"},
+			want: func(o *Options) {
+				o.Format = FormatLatex
+				o.LatexGantt = true
+			},
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:     "cores",
+			args:     []string{"binary_name", "-cores", "4", "file.csv"},
+			want:     func(o *Options) { o.Cores = 4 },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:     "migration cost",
+			args:     []string{"binary_name", "-cores", "2", "-migration-cost", "3", "file.csv"},
+			want:     func(o *Options) { o.Cores, o.MigrationCost = 2, 3 },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:     "dispatch latency",
+			args:     []string{"binary_name", "-dispatch-latency", "2", "file.csv"},
+			want:     func(o *Options) { o.DispatchLatency = 2 },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:     "workload stats",
+			args:     []string{"binary_name", "-workload-stats", "file.csv"},
+			want:     func(o *Options) { o.WorkloadStats = true },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:    "workload stats with json",
+			args:    []string{"binary_name", "-workload-stats", "-format", "json", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:     "abort at deadline",
+			args:     []string{"binary_name", "-abort-at-deadline", "file.csv"},
+			want:     func(o *Options) { o.AbortAtDeadline = true },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:     "repeatable hash",
+			args:     []string{"binary_name", "-repeatable-hash", "file.csv"},
+			want:     func(o *Options) { o.RepeatableHash = true },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:     "compare preemption",
+			args:     []string{"binary_name", "-compare-preemption", "file.csv"},
+			want:     func(o *Options) { o.ComparePreemption = true },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:     "wait timeline",
+			args:     []string{"binary_name", "-wait-timeline", "2, 3", "file.csv"},
+			want:     func(o *Options) { o.WaitTimeline = []int64{2, 3} },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:    "wait timeline not an ID",
+			args:    []string{"binary_name", "-wait-timeline", "two", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:     "export processes",
+			args:     []string{"binary_name", "-export-processes", "json", "file.csv"},
+			want:     func(o *Options) { o.ExportProcesses = InputJSON },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:    "unknown export format",
+			args:    []string{"binary_name", "-export-processes", "xml", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:     "profile",
+			args:     []string{"binary_name", "-profile", "cpu.prof", "file.csv"},
+			want:     func(o *Options) { o.Profile = "cpu.prof" },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:     "utility decay",
+			args:     []string{"binary_name", "-utility-decay", "0", "file.csv"},
+			want:     func(o *Options) { o.UtilityDecay = 0 },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:    "negative utility decay",
+			args:    []string{"binary_name", "-utility-decay", "-1", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:     "inspect at",
+			args:     []string{"binary_name", "-algo", "sjf", "-inspect-at", "3, 7,10", "file.csv"},
+			want:     func(o *Options) { o.Algorithms, o.InspectAt = []string{"sjf"}, []int64{3, 7, 10} },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:    "inspect at without algo",
+			args:    []string{"binary_name", "-inspect-at", "3", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:    "inspect at uninspectable algo",
+			args:    []string{"binary_name", "-algo", "rr", "-inspect-at", "3", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:    "tui with pinned output order",
+			args:    []string{"binary_name", "-tui", "-pin-output-order", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:     "no wrapping",
+			args:     []string{"binary_name", "-width", "0", "file.csv"},
+			want:     func(o *Options) { o.widthGiven = true },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:    "negative dispatch latency",
+			args:    []string{"binary_name", "-dispatch-latency", "-1", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:    "negative migration cost",
+			args:    []string{"binary_name", "-migration-cost", "-1", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:     "algorithms",
+			args:     []string{"binary_name", "-algo", "stride, fcfs", "file.csv"},
+			want:     func(o *Options) { o.Algorithms = []string{"stride", "fcfs"} },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:    "unknown algorithm",
+			args:    []string{"binary_name", "-algo", "fcfs,lottery", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:     "steady-state window",
+			args:     []string{"binary_name", "-warmup", "10", "-window", "50", "file.csv"},
+			want:     func(o *Options) { o.Warmup, o.Window = 10, 50 },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:    "window before warmup",
+			args:    []string{"binary_name", "-warmup", "10", "-window", "5", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:     "gantt scale",
+			args:     []string{"binary_name", "-gantt-scale", "0.5", "file.csv"},
+			want:     func(o *Options) { o.GanttScale, o.GanttAxis = 0.5, true },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:    "negative gantt scale",
+			args:    []string{"binary_name", "-gantt-scale", "-1", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:     "max time",
+			args:     []string{"binary_name", "-max-time", "100", "file.csv"},
+			want:     func(o *Options) { o.MaxTime = 100 },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:    "negative max time",
+			args:    []string{"binary_name", "-max-time", "-1", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:     "frequencies",
+			args:     []string{"binary_name", "-frequencies", "1, 0.75,0.5", "-dvfs-policy", "powersave", "file.csv"},
+			want:     func(o *Options) { o.Frequencies, o.DVFSPolicy = []float64{1, 0.75, 0.5}, DVFSPowersave },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:    "zero frequency",
+			args:    []string{"binary_name", "-frequencies", "1,0", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:     "tie-break",
+			args:     []string{"binary_name", "-tiebreak", "fifo", "file.csv"},
+			want:     func(o *Options) { o.TieBreak = TieBreakFIFO },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:    "unknown tie-break",
+			args:    []string{"binary_name", "-tiebreak", "random", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:     "sjf tie",
+			args:     []string{"binary_name", "-sjf-tie", "best-fit", "file.csv"},
+			want:     func(o *Options) { o.SJFTie = SJFBestFit },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:    "unknown sjf tie",
+			args:    []string{"binary_name", "-sjf-tie", "worst-fit", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:     "columns",
+			args:     []string{"binary_name", "-columns", "arrival,burst,id", "file.csv"},
+			want:     func(o *Options) { o.Columns = []string{"arrival", "burst", "id"} },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:    "columns missing id",
+			args:    []string{"binary_name", "-columns", "arrival,burst", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:     "priority order",
+			args:     []string{"binary_name", "-priority-order", "desc", "file.csv"},
+			want:     func(o *Options) { o.PriorityOrder = PriorityDescending },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:    "unknown priority order",
+			args:    []string{"binary_name", "-priority-order", "up", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:     "theme",
+			args:     []string{"binary_name", "-theme", "compact", "file.csv"},
+			want:     func(o *Options) { o.Theme = ThemeCompact },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:    "unknown theme",
+			args:    []string{"binary_name", "-theme", "neon", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:     "ljust",
+			args:     []string{"binary_name", "-ljust", "file.csv"},
+			want:     func(o *Options) { o.GanttJustify = JustifyLeft },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:     "split idle",
+			args:     []string{"binary_name", "-gantt-merge-idle=false", "file.csv"},
+			want:     func(o *Options) { o.GanttMergeIdle = false },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:    "ljust and rjust",
+			args:    []string{"binary_name", "-ljust", "-rjust", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:     "group by",
+			args:     []string{"binary_name", "-group-by", "core", "file.csv"},
+			want:     func(o *Options) { o.GroupBy = GroupByCore },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:    "unknown group by",
+			args:    []string{"binary_name", "-group-by", "deadline", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:     "compact gantt",
+			args:     []string{"binary_name", "-compact-gantt", "file.csv"},
+			want:     func(o *Options) { o.CompactGantt = true },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:     "limit",
+			args:     []string{"binary_name", "-limit", "5", "file.csv"},
+			want:     func(o *Options) { o.Limit = 5 },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:    "negative limit",
+			args:    []string{"binary_name", "-limit", "-1", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:     "relative times",
+			args:     []string{"binary_name", "-relative-times", "file.csv"},
+			want:     func(o *Options) { o.RelativeTimes = true },
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:    "zero cores",
+			args:    []string{"binary_name", "-cores", "0", "file.csv"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown format",
+			args:    []string{"binary_name", "-format", "docx", "file.csv"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, gotArgs, err := parseOptions(tt.args...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			want := DefaultOptions()
+			tt.want(&want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("parseOptions() got = %v, want %v", got, want)
+			}
+			if strings.Join(gotArgs, " ") != strings.Join(tt.wantArgs, " ") {
+				t.Errorf("parseOptions() args = %v, want %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestOptions_before(t *testing.T) {
+	t.Parallel()
+	// Every process has the same deadline, so EDF is left to the tie-break. They're listed out of PID order,
+	// the later arrivals in reverse.
+	processes := []Process{
+		{ProcessID: 3, ArrivalTime: 0, BurstDuration: 2, Deadline: 10},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Deadline: 10},
+		{ProcessID: 1, ArrivalTime: 1, BurstDuration: 2, Deadline: 10},
+	}
+	tests := []struct {
+		tieBreak string
+		want     []TimeSlice
+	}{
+		{
+			// Process 3 arrived first so keeps the CPU, then the PIDs break the tie between the later two.
+			tieBreak: TieBreakArrival,
+			want:     []TimeSlice{{PID: 3, Start: 0, Stop: 2}, {PID: 1, Start: 2, Stop: 4}, {PID: 2, Start: 4, Stop: 6}},
+		},
+		{
+			// Process 1 preempts process 3 as soon as it arrives.
+			tieBreak: TieBreakPID,
+			want: []TimeSlice{
+				{PID: 3, Start: 0, Stop: 1},
+				{PID: 1, Start: 1, Stop: 3},
+				{PID: 2, Start: 3, Stop: 5},
+				{PID: 3, Start: 5, Stop: 6},
+			},
+		},
+		{
+			// Processes run in the order they were queued, the later two in the input's order.
+			tieBreak: TieBreakFIFO,
+			want:     []TimeSlice{{PID: 3, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 1, Start: 4, Stop: 6}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.tieBreak, func(t *testing.T) {
+			t.Parallel()
+			opts := DefaultOptions()
+			opts.TieBreak = tt.tieBreak
+			if got := EDFSchedule("EDF", processes, opts).Gantt; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EDFSchedule() with %v tie-break = %v, want %v", tt.tieBreak, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSJFSchedule_tie(t *testing.T) {
+	t.Parallel()
+	// Processes 3, 1 and 2 are all ready with the same burst when process 5 completes, process 3 having waited
+	// longest.
+	processes := []Process{
+		{ProcessID: 5, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 4},
+		{ProcessID: 1, ArrivalTime: 2, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 2, BurstDuration: 4},
+	}
+	tests := []struct {
+		policy string
+		want   []int64
+	}{
+		{policy: SJFFirstFit, want: []int64{5, 3, 1, 2}},
+		{policy: SJFBestFit, want: []int64{5, 1, 2, 3}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.policy, func(t *testing.T) {
+			t.Parallel()
+			opts := DefaultOptions()
+			opts.SJFTie = tt.policy
+			gantt := SJFSchedule("SJF", processes, opts).Gantt
+			got := make([]int64, len(gantt))
+			for i := range gantt {
+				got[i] = gantt[i].PID
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SJFSchedule() with %v ran %v, want %v", tt.policy, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSJFPrioritySchedule_priorityOrder(t *testing.T) {
+	t.Parallel()
+	// Equal bursts arriving together, so only their priorities order them.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 0, BurstDuration: 2, Priority: 3},
+	}
+	tests := []struct {
+		order   string
+		want    []int64
+		highest int64
+	}{
+		{order: PriorityAscending, want: []int64{2, 1, 3}, highest: 1},
+		{order: PriorityDescending, want: []int64{3, 1, 2}, highest: 3},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.order, func(t *testing.T) {
+			t.Parallel()
+			opts := DefaultOptions()
+			opts.PriorityOrder = tt.order
+			result := SJFPrioritySchedule("Priority", processes, opts)
+			got := make([]int64, len(result.Gantt))
+			for i := range result.Gantt {
+				got[i] = result.Gantt[i].PID
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SJFPrioritySchedule() with %v ran %v, want %v", tt.order, got, tt.want)
+			}
+			if first := priorityStats(result.Stats, opts)[0].Priority; first != tt.highest {
+				t.Errorf("priorityStats() with %v starts with priority %v, want %v", tt.order, first, tt.highest)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// instanceIDBase spaces the IDs of a periodic task's instances, instance n of task t being t*instanceIDBase + n,
+// so a task can have at most instanceIDBase-1 instances.
+const instanceIDBase = 1000
+
+// expandInstances replaces every periodic process with more than one instance by its instances, the nth
+// arriving n-1 periods after the process, with its deadline, if it has one, n-1 periods later too.
+// The instances are in the order of their task, so aren't sorted by arrival.
+func expandInstances(processes []Process) ([]Process, error) {
+	ids := make(map[int64]bool, len(processes))
+	for _, p := range processes {
+		ids[p.ProcessID] = true
+	}
+
+	expanded := make([]Process, 0, len(processes))
+	for _, p := range processes {
+		switch {
+		case p.Instances < 0:
+			return nil, fmt.Errorf("%w: process %v has negative instances", ErrInvalidArgs, p.ProcessID)
+		case p.Instances <= 1:
+			expanded = append(expanded, p)
+			continue
+		case p.Period <= 0:
+			return nil, fmt.Errorf("%w: process %v has %v instances but no period", ErrInvalidArgs, p.ProcessID, p.Instances)
+		case p.Instances >= instanceIDBase:
+			return nil, fmt.Errorf("%w: process %v has %v instances, at most %v are allowed",
+				ErrInvalidArgs, p.ProcessID, p.Instances, instanceIDBase-1)
+		}
+		for n := int64(1); n <= p.Instances; n++ {
+			instance := p
+			instance.ProcessID = p.ProcessID*instanceIDBase + n
+			if ids[instance.ProcessID] {
+				return nil, fmt.Errorf("%w: instance %v of process %v has the ID of another process",
+					ErrInvalidArgs, n, p.ProcessID)
+			}
+			instance.Task, instance.Instance = p.ProcessID, n
+			instance.ArrivalTime += (n - 1) * p.Period
+			if p.Deadline != 0 {
+				instance.Deadline += (n - 1) * p.Period
+			}
+			expanded = append(expanded, instance)
+		}
+	}
+
+	return expanded, nil
+}
+
+// TaskStats are the averages of a periodic task's instances.
+type TaskStats struct {
+	Task            int64
+	Instances       int
+	AveWait         float64
+	AveTurnaround   float64
+	WorstTurnaround int64
+	// Missed is how many instances completed after their deadline.
+	Missed int
+}
+
+// hasInstances reports if any of the processes is an instance of a periodic task.
+func hasInstances(processes []Process) bool {
+	for i := range processes {
+		if processes[i].Instance != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// taskStats averages the stats of each periodic task's instances, in task order, leaving out other processes.
+func taskStats(stats []ProcessStats) []TaskStats {
+	byTask := make(map[int64]*TaskStats)
+	for _, s := range stats {
+		if s.Instance == 0 {
+			continue
+		}
+		t, ok := byTask[s.Task]
+		if !ok {
+			t = &TaskStats{Task: s.Task}
+			byTask[s.Task] = t
+		}
+		t.Instances++
+		t.AveWait += float64(s.Wait)
+		t.AveTurnaround += float64(s.Turnaround)
+		if s.Turnaround > t.WorstTurnaround {
+			t.WorstTurnaround = s.Turnaround
+		}
+		if s.Deadline != 0 && s.Completion > s.Deadline {
+			t.Missed++
+		}
+	}
+
+	tasks := make([]TaskStats, 0, len(byTask))
+	for _, t := range byTask {
+		t.AveWait /= float64(t.Instances)
+		t.AveTurnaround /= float64(t.Instances)
+		tasks = append(tasks, *t)
+	}
+	sort.Slice(tasks, func(a, b int) bool { return tasks[a].Task < tasks[b].Task })
+
+	return tasks
+}
+
+func outputTaskStats(w io.Writer, tasks []TaskStats, opts Options) {
+	scale := float64(opts.ticksPerUnit())
+	_, _ = fmt.Fprintln(w, "Periodic tasks")
+	table := newTable(w, opts)
+	table.SetHeader([]string{"Task", "Instances", "Average wait", "Average turnaround", "Worst turnaround", "Missed"})
+	for _, t := range tasks {
+		table.Append([]string{
+			fmt.Sprint(t.Task),
+			fmt.Sprint(t.Instances),
+			formatFloat(t.AveWait/scale, opts.Precision),
+			formatFloat(t.AveTurnaround/scale, opts.Precision),
+			formatTime(t.WorstTurnaround, opts.TimeScale),
+			fmt.Sprint(t.Missed),
+		})
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w)
+}
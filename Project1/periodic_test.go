@@ -0,0 +1,81 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_expandInstances(t *testing.T) {
+	t.Parallel()
+	processes, _, err := loadProcesses(strings.NewReader("1,2,0,1,0,3,,5,0,0,3\n2,1,1,2,0,0,,0,0,0,0\n"))
+	if err != nil {
+		t.Fatalf("loadProcesses() error = %v", err)
+	}
+	got, err := expandInstances(processes)
+	if err != nil {
+		t.Fatalf("expandInstances() error = %v", err)
+	}
+	task := Process{BurstDuration: 2, Priority: 1, Period: 5, Instances: 3, Task: 1, Line: 1}
+	instance := func(n, arrival, deadline int64) Process {
+		p := task
+		p.ProcessID, p.Instance, p.ArrivalTime, p.Deadline = 1000+n, n, arrival, deadline
+		return p
+	}
+	want := []Process{
+		instance(1, 0, 3),
+		instance(2, 5, 8),
+		instance(3, 10, 13),
+		{ProcessID: 2, BurstDuration: 1, ArrivalTime: 1, Priority: 2, Line: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expandInstances() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_expandInstances_invalid(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+	}{
+		{name: "no period", processes: []Process{{ProcessID: 1, BurstDuration: 1, Instances: 2}}},
+		{name: "negative", processes: []Process{{ProcessID: 1, BurstDuration: 1, Period: 5, Instances: -1}}},
+		{name: "too many", processes: []Process{{ProcessID: 1, BurstDuration: 1, Period: 5, Instances: instanceIDBase}}},
+		{
+			name: "ID taken",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 1, Period: 5, Instances: 2},
+				{ProcessID: 1002, BurstDuration: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := expandInstances(tt.processes); err == nil {
+				t.Errorf("expandInstances() error = nil, want one")
+			}
+		})
+	}
+}
+
+func Test_taskStats(t *testing.T) {
+	t.Parallel()
+	// The second instance of task 1 waits behind process 2, missing its deadline.
+	processes, err := expandInstances([]Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Period: 4, Deadline: 3, Instances: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 4},
+	})
+	if err != nil {
+		t.Fatalf("expandInstances() error = %v", err)
+	}
+	sortByArrival(processes, DefaultOptions())
+	result := FCFSSchedule("FCFS", processes, DefaultOptions())
+
+	want := []TaskStats{{Task: 1, Instances: 2, AveWait: 1.5, AveTurnaround: 3.5, WorstTurnaround: 5, Missed: 1}}
+	if got := taskStats(result.Stats); !reflect.DeepEqual(got, want) {
+		t.Errorf("taskStats() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// preemptionPolicy is a base policy's pick, which -compare-preemption runs both preemptively, picking again at
+// every event, and non-preemptively, letting the process picked run until it completes or blocks.
+type preemptionPolicy struct {
+	name string
+	pick func(opts Options) func(ready []*runnable, time int64) int
+}
+
+// preemptionPolicies are the base policies -compare-preemption compares, shortest-job-first against
+// shortest-remaining-time-first and non-preemptive against preemptive priority.
+var preemptionPolicies = []preemptionPolicy{
+	{"Shortest job", pickShortest},
+	{"Priority", pickHighestPriority},
+}
+
+// pickShortest picks the ready process with the least left to run, ties going by opts.TieBreak.
+func pickShortest(opts Options) func(ready []*runnable, time int64) int {
+	return func(ready []*runnable, _ int64) int {
+		best := 0
+		for i := 1; i < len(ready); i++ {
+			a, b := ready[i], ready[best]
+			if a.Remaining < b.Remaining || a.Remaining == b.Remaining && opts.before(a.Process, b.Process, false) {
+				best = i
+			}
+		}
+		return best
+	}
+}
+
+// pickHighestPriority picks the ready process with the highest priority by opts.PriorityOrder, ties going by
+// opts.TieBreak.
+func pickHighestPriority(opts Options) func(ready []*runnable, time int64) int {
+	return queuedPick(func(a, b *runnable) bool {
+		if a.Priority != b.Priority {
+			return opts.higherPriority(a.Priority, b.Priority)
+		}
+		return opts.before(a.Process, b.Process, false)
+	})
+}
+
+// PreemptionComparison is a base policy's results with and without preemption.
+type PreemptionComparison struct {
+	Policy        string
+	Preemptive    SchedulerResult
+	NonPreemptive SchedulerResult
+}
+
+// comparePreemption schedules the processes with every policy of preemptionPolicies, preemptively and not.
+func comparePreemption(processes []Process, opts Options) []PreemptionComparison {
+	schedule := func(title string, pick func(ready []*runnable, time int64) int) SchedulerResult {
+		gantt, ioGantt, err := preemptiveSchedule(processes, 0, opts, pick)
+		result := calculateCompletionStats(title, processes, gantt)
+		result.IOGantt = ioGantt
+		result.Err = err
+		return result
+	}
+	comparisons := make([]PreemptionComparison, len(preemptionPolicies))
+	for i, p := range preemptionPolicies {
+		comparisons[i] = PreemptionComparison{
+			Policy:        p.name,
+			Preemptive:    schedule(p.name+" (preemptive)", p.pick(opts)),
+			NonPreemptive: schedule(p.name+" (non-preemptive)", nonPreemptive(p.pick(opts))),
+		}
+	}
+
+	return comparisons
+}
+
+// outputPreemptionComparison writes the averages and context switches of each policy's two variants side by side.
+func outputPreemptionComparison(w io.Writer, comparisons []PreemptionComparison, opts Options) {
+	_, _ = fmt.Fprintln(w, "Preemptive against non-preemptive")
+	table := newTable(w, opts)
+	table.SetHeader([]string{"Policy", "Variant", "Wait", "Turnaround", "Throughput", "Switches"})
+	for _, c := range comparisons {
+		for _, v := range []struct {
+			name   string
+			result SchedulerResult
+		}{
+			{"Preemptive", c.Preemptive},
+			{"Non-preemptive", c.NonPreemptive},
+		} {
+			wait, turnaround, throughput := v.result.averages(opts.TimeScale)
+			table.Append([]string{
+				c.Policy,
+				v.name,
+				formatFloat(wait, opts.Precision),
+				formatFloat(turnaround, opts.Precision),
+				formatFloat(throughput, opts.Precision),
+				fmt.Sprint(contextSwitches(v.result.Gantt)),
+			})
+		}
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_comparePreemption(t *testing.T) {
+	t.Parallel()
+	// Each later arrival is both shorter and of higher priority than the process running.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 8, Priority: 3},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 4, Priority: 2},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1, Priority: 1},
+	}
+	comparisons := comparePreemption(processes, DefaultOptions())
+	if len(comparisons) != len(preemptionPolicies) {
+		t.Fatalf("comparePreemption() = %v comparisons, want %v", len(comparisons), len(preemptionPolicies))
+	}
+	for _, c := range comparisons {
+		preemptive, nonPreemptive := contextSwitches(c.Preemptive.Gantt), contextSwitches(c.NonPreemptive.Gantt)
+		if preemptive <= nonPreemptive {
+			t.Errorf("comparePreemption() %v switches = %v preemptive, %v not, want more preemptive",
+				c.Policy, preemptive, nonPreemptive)
+		}
+		if c.Preemptive.Err != nil || c.NonPreemptive.Err != nil {
+			t.Errorf("comparePreemption() %v errors = %v, %v", c.Policy, c.Preemptive.Err, c.NonPreemptive.Err)
+		}
+	}
+
+	var w bytes.Buffer
+	opts := DefaultOptions()
+	opts.Plain = true
+	outputPreemptionComparison(&w, comparisons, opts)
+	for _, want := range []string{"Shortest job", "Priority", "Non-preemptive", "Switches"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputPreemptionComparison() missing %q: %v", want, w.String())
+		}
+	}
+}
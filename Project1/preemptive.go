@@ -0,0 +1,376 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrNoProgress is returned when a scheduler stops its processes from ever completing.
+var ErrNoProgress = errors.New("scheduler made no progress")
+
+// watchdogIterations is how many times the event loop may go round without time advancing or a process completing
+// before it gives up. Events at the same time only take a few iterations each, so this is only reached by a
+// scheduler that's stuck.
+const watchdogIterations = 1000
+
+// watchdog notices a simulation loop going round without time advancing or processes completing, so a scheduler
+// that's stuck can stop with ErrNoProgress instead of hanging.
+type watchdog struct {
+	lastTime int64
+	lastLeft int
+	stalled  int
+}
+
+// newWatchdog is a watchdog for a loop that hasn't started.
+func newWatchdog() *watchdog {
+	return &watchdog{lastTime: -1}
+}
+
+// stuck is called once per iteration of the loop with the time and how many processes haven't completed,
+// reporting whether neither has changed for more than watchdogIterations iterations.
+func (w *watchdog) stuck(time int64, left int) bool {
+	if time != w.lastTime || left != w.lastLeft {
+		w.lastTime, w.lastLeft, w.stalled = time, left, 0
+		return false
+	}
+	w.stalled++
+
+	return w.stalled > watchdogIterations
+}
+
+// noProgress is the ErrNoProgress for the processes that never completed, stuck at time.
+func noProgress(pids []int64, time int64) error {
+	return fmt.Errorf("%w: processes %v never completed, stuck at time %v", ErrNoProgress, pids, time)
+}
+
+// runnable is a process waiting in, or running from, a ready queue.
+type runnable struct {
+	Process
+	Remaining int64
+	// NextIO indexes the process's next I/O request.
+	NextIO int
+}
+
+// untilIO is how much more CPU time the process needs before its next I/O request, or -1 if it has none left.
+func (r *runnable) untilIO() int64 {
+	if r.NextIO >= len(r.IO) {
+		return -1
+	}
+
+	return r.IO[r.NextIO].At - (r.BurstDuration - r.Remaining)
+}
+
+// preemptiveSchedule runs a single core from a ready queue, calling pick to choose which ready process
+// runs whenever a process arrives, finishes, blocks for or returns from I/O, or has run for a quantum.
+// A zero quantum lets the picked process run until one of the other events. pick returns an index into ready,
+// or any other number to leave the CPU idle until the next event. Picks ordering by something that changes as
+// time passes for processes that aren't running, such as the time left, a response ratio or a utility, scan
+// ready each time; picks with fixed keys, like a deadline or a priority, keep it in a ReadyQueue. If time
+// stops advancing with processes unfinished the error is ErrNoProgress, naming them, so a broken scheduler
+// can't hang the simulation.
+// Processes making I/O requests queue first-come, first-serve for a single I/O device, whose use is returned
+// as the second gantt. A process finishing I/O rejoins the back of the ready queue ahead of processes arriving
+// at the same time, and processes arriving together are queued in the order of opts.TieBreak.
+// Events at the same instant all take effect before pick is called, so a process arriving just as another
+// completes or is preempted is ready for the same pick.
+// With a positive opts.MaxTime the simulation stops at the first event from then on. Each dispatch of a process
+// that wasn't already running takes opts.DispatchLatency, with the CPU idle, before the process starts. pick isn't
+// called again for the process switched to, so events during the switch are decided on at the next event after
+// it starts.
+func preemptiveSchedule(
+	inputProcesses []Process,
+	quantum int64,
+	opts Options,
+	pick func(ready []*runnable, time int64) int,
+) ([]TimeSlice, []TimeSlice, error) {
+	e := newEngine(inputProcesses, opts)
+	err := e.run(quantum, pick, opts.until())
+
+	return e.Gantt, e.IOGantt, err
+}
+
+// engine is the state of a preemptiveSchedule simulation between events, everything needed to carry on from
+// where it left off, see Snapshot. Every process is in exactly one of Pending, Ready, Blocked or Device until
+// it completes.
+type engine struct {
+	Time int64 `json:"time"`
+	// Pending are the processes yet to arrive, in the order they'll be queued.
+	Pending []*runnable `json:"pending"`
+	Ready   []*runnable `json:"ready"`
+	// Blocked are queued for the I/O device, which Device is using until IOEnd.
+	Blocked []*runnable `json:"blocked"`
+	Device  *runnable   `json:"device,omitempty"`
+	IOEnd   int64       `json:"io_end"`
+	Gantt   []TimeSlice `json:"gantt"`
+	IOGantt []TimeSlice `json:"io_gantt"`
+	// AbortAtDeadline stops a process that hasn't completed by its deadline, recording it in Aborted.
+	AbortAtDeadline bool    `json:"abort_at_deadline,omitempty"`
+	Aborted         []Abort `json:"aborted,omitempty"`
+	// DispatchLatency is how long switching to a process takes, and Dispatched the process last switched to that
+	// hasn't started running, -1 if none.
+	DispatchLatency int64 `json:"dispatch_latency,omitempty"`
+	Dispatched      int64 `json:"dispatched"`
+	// inspectAt are the times, in order, still to be inspected, each recorded in inspections, see inspectSchedule.
+	inspectAt   []int64
+	inspections []Inspection
+	// ctx abandons the simulation once it's done, see Options.WithContext.
+	ctx context.Context
+}
+
+// newEngine is the state of a simulation of the processes that hasn't started yet.
+func newEngine(inputProcesses []Process, opts Options) *engine {
+	e := &engine{
+		Pending: make([]*runnable, len(inputProcesses)),
+		Ready:   make([]*runnable, 0),
+		Blocked: make([]*runnable, 0),
+		Gantt:   make([]TimeSlice, 0),
+		IOGantt: make([]TimeSlice, 0),
+		// Dispatch latency is the run's, in ticks like every other time of the engine.
+		DispatchLatency: opts.DispatchLatency,
+		Dispatched:      -1,
+		ctx:             opts.ctx,
+	}
+	for i := range inputProcesses {
+		e.Pending[i] = &runnable{Process: inputProcesses[i], Remaining: inputProcesses[i].BurstDuration}
+	}
+	sort.SliceStable(e.Pending, func(a, b int) bool {
+		if e.Pending[a].ArrivalTime == e.Pending[b].ArrivalTime {
+			return opts.before(e.Pending[a].Process, e.Pending[b].Process, false)
+		}
+		return e.Pending[a].ArrivalTime < e.Pending[b].ArrivalTime
+	})
+
+	return e
+}
+
+// done reports whether every process has completed.
+func (e *engine) done() bool {
+	return len(e.Pending) == 0 && len(e.Ready) == 0 && e.Device == nil
+}
+
+// dispatchedIndex indexes the ready process the CPU has been switched to but hasn't started running, or is -1.
+func (e *engine) dispatchedIndex() int {
+	if e.Dispatched < 0 {
+		return -1
+	}
+	for i, r := range e.Ready {
+		if r.ProcessID == e.Dispatched {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// lastPicked is the ready process picked last, the one switched to or else the one that ran last, or nil.
+func (e *engine) lastPicked() *runnable {
+	if i := e.dispatchedIndex(); i >= 0 {
+		return e.Ready[i]
+	}
+	if n := len(e.Gantt); n > 0 {
+		for _, r := range e.Ready {
+			if r.ProcessID == e.Gantt[n-1].PID {
+				return r
+			}
+		}
+	}
+
+	return nil
+}
+
+// left is how many processes haven't completed.
+func (e *engine) left() int {
+	left := len(e.Pending) + len(e.Ready) + len(e.Blocked)
+	if e.Device != nil {
+		left++
+	}
+
+	return left
+}
+
+// startIO gives the I/O device to the first blocked process if it's free.
+func (e *engine) startIO() {
+	if e.Device != nil || len(e.Blocked) == 0 {
+		return
+	}
+	e.Device, e.Blocked = e.Blocked[0], e.Blocked[1:]
+	e.IOEnd = e.Time + e.Device.IO[e.Device.NextIO].Duration
+	e.IOGantt = append(e.IOGantt, TimeSlice{PID: e.Device.ProcessID, Start: e.Time, Stop: e.IOEnd})
+}
+
+// stuck is the ErrNoProgress naming the processes that haven't completed.
+func (e *engine) stuck() error {
+	pids := make([]int64, 0)
+	for _, r := range append(append(append(e.Ready, e.Blocked...), e.Pending...), e.Device) {
+		if r != nil {
+			pids = append(pids, r.ProcessID)
+		}
+	}
+
+	return noProgress(pids, e.Time)
+}
+
+// run simulates until every process completes, or until the first event at or after until if it isn't negative,
+// leaving the engine ready to run on from there. If the engine's context is done it stops with the context's error.
+// See preemptiveSchedule.
+func (e *engine) run(quantum int64, pick func(ready []*runnable, time int64) int, until int64) error {
+	watchdog := newWatchdog()
+	for !e.done() {
+		if until >= 0 && e.Time >= until {
+			return nil
+		}
+		if err := contextErr(e.ctx); err != nil {
+			return err
+		}
+		if watchdog.stuck(e.Time, e.left()) {
+			return e.stuck()
+		}
+		if e.Device != nil && e.IOEnd <= e.Time {
+			e.Device.NextIO++
+			e.Ready = append(e.Ready, e.Device)
+			e.Device = nil
+			e.startIO()
+		}
+		for len(e.Pending) > 0 && e.Pending[0].ArrivalTime <= e.Time {
+			e.Ready = append(e.Ready, e.Pending[0])
+			e.Pending = e.Pending[1:]
+		}
+		if e.AbortAtDeadline {
+			e.abortLate()
+		}
+
+		// The next time something other than the running process could change what should run.
+		next := int64(-1)
+		if len(e.Pending) > 0 {
+			next = e.Pending[0].ArrivalTime
+		}
+		if e.Device != nil && (next < 0 || e.IOEnd < next) {
+			next = e.IOEnd
+		}
+		if e.AbortAtDeadline {
+			for _, r := range e.Ready {
+				if r.Deadline > e.Time && (next < 0 || r.Deadline < next) {
+					next = r.Deadline
+				}
+			}
+		}
+
+		if len(e.Ready) == 0 {
+			e.inspect(next, nil)
+			// Nothing is left to wait for if the last processes were aborted.
+			if next >= 0 {
+				e.Time = next
+			}
+			continue
+		}
+
+		// A process switched to runs without being picked again, as picks can charge it for being chosen.
+		i := e.dispatchedIndex()
+		if i < 0 {
+			i = pick(e.Ready, e.Time)
+		}
+		if i < 0 || i >= len(e.Ready) {
+			if next < 0 {
+				return e.stuck()
+			}
+			e.inspect(next, nil)
+			e.Time = next
+			continue
+		}
+		running := e.Ready[i]
+		continues := len(e.Gantt) > 0 && e.Gantt[len(e.Gantt)-1].PID == running.ProcessID &&
+			e.Gantt[len(e.Gantt)-1].Stop == e.Time
+		if e.DispatchLatency > 0 && !continues && e.Dispatched != running.ProcessID {
+			e.inspect(e.Time+e.DispatchLatency, nil)
+			e.Time += e.DispatchLatency
+			e.Dispatched = running.ProcessID
+			// Something happened while switching, so take it in before the process starts.
+			if next >= 0 && next <= e.Time {
+				continue
+			}
+		}
+		e.Dispatched = -1
+		run := running.Remaining
+		if untilIO := running.untilIO(); untilIO >= 0 && untilIO < run {
+			run = untilIO
+		}
+		if quantum > 0 && quantum < run {
+			run = quantum
+		}
+		if next >= 0 && next-e.Time < run {
+			run = next - e.Time
+		}
+		e.inspect(e.Time+run, running)
+
+		if n := len(e.Gantt); n > 0 && e.Gantt[n-1].PID == running.ProcessID && e.Gantt[n-1].Stop == e.Time {
+			e.Gantt[n-1].Stop += run
+		} else {
+			e.Gantt = append(e.Gantt, TimeSlice{PID: running.ProcessID, Start: e.Time, Stop: e.Time + run})
+		}
+		e.Time += run
+		running.Remaining -= run
+		switch {
+		case running.Remaining <= 0:
+			e.Ready = append(e.Ready[:i], e.Ready[i+1:]...)
+		case running.untilIO() == 0:
+			e.Ready = append(e.Ready[:i], e.Ready[i+1:]...)
+			e.Blocked = append(e.Blocked, running)
+			e.startIO()
+		}
+	}
+	e.inspect(-1, nil)
+
+	return nil
+}
+
+// inspect records the inspections due before stop, or all of them if it's negative, while running is on the CPU
+// from now until then with the rest of the ready queue waiting. running is nil if the CPU is idle.
+func (e *engine) inspect(stop int64, running *runnable) {
+	for len(e.inspectAt) > 0 && (stop < 0 || e.inspectAt[0] < stop) {
+		at := e.inspectAt[0]
+		e.inspectAt = e.inspectAt[1:]
+		inspection := Inspection{Time: at, Ready: make([]QueuedProcess, 0, len(e.Ready))}
+		for _, r := range e.Ready {
+			if r == running {
+				inspection.Running = &QueuedProcess{PID: r.ProcessID, Remaining: r.Remaining - (at - e.Time)}
+				continue
+			}
+			inspection.Ready = append(inspection.Ready, QueuedProcess{PID: r.ProcessID, Remaining: r.Remaining})
+		}
+		e.inspections = append(e.inspections, inspection)
+	}
+}
+
+// abortLate takes the ready processes whose deadline has passed out of the ready queue, recording them as aborted.
+// A process blocked for I/O at its deadline is aborted once it's ready again.
+func (e *engine) abortLate() {
+	ready := e.Ready[:0]
+	for _, r := range e.Ready {
+		if r.Deadline > 0 && r.Deadline <= e.Time {
+			e.Aborted = append(e.Aborted, Abort{PID: r.ProcessID, Time: e.Time, Ran: r.BurstDuration - r.Remaining})
+			continue
+		}
+		ready = append(ready, r)
+	}
+	e.Ready = ready
+}
+
+// nonPreemptive wraps pick so a process that has started keeps the CPU until it finishes or blocks for I/O.
+func nonPreemptive(pick func(ready []*runnable, time int64) int) func(ready []*runnable, time int64) int {
+	var last *runnable
+	return func(ready []*runnable, time int64) int {
+		for i := range ready {
+			if ready[i] == last {
+				return i
+			}
+		}
+		i := pick(ready, time)
+		if i >= 0 && i < len(ready) {
+			last = ready[i]
+		}
+
+		return i
+	}
+}
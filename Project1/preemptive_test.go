@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func Test_preemptiveSchedule_noProgress(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 2},
+	}
+	// The broken scheduler only ever runs process 1, refusing the CPU to everything else.
+	gantt, _, err := preemptiveSchedule(processes, 0, DefaultOptions(), func(ready []*runnable, _ int64) int {
+		for i, r := range ready {
+			if r.ProcessID == 1 {
+				return i
+			}
+		}
+		return -1
+	})
+	if !errors.Is(err, ErrNoProgress) {
+		t.Fatalf("preemptiveSchedule() error = %v, want %v", err, ErrNoProgress)
+	}
+	if !strings.Contains(err.Error(), "[2 3]") {
+		t.Errorf("preemptiveSchedule() error = %v, should name processes 2 and 3", err)
+	}
+	if len(gantt) != 1 || gantt[0].PID != 1 {
+		t.Errorf("preemptiveSchedule() gantt = %v, want only process 1's run", gantt)
+	}
+}
+
+func Test_watchdog(t *testing.T) {
+	t.Parallel()
+	w := newWatchdog()
+	for i := 0; i < watchdogIterations; i++ {
+		if w.stuck(5, 2) {
+			t.Fatalf("watchdog.stuck() after %v iterations, want it to wait for %v", i, watchdogIterations)
+		}
+	}
+	// A process completing is progress even though time hasn't moved.
+	if w.stuck(5, 1) {
+		t.Fatal("watchdog.stuck() when a process completed")
+	}
+	for i := 0; i < watchdogIterations; i++ {
+		w.stuck(5, 1)
+	}
+	if !w.stuck(5, 1) {
+		t.Errorf("watchdog.stuck() = false after %v iterations without progress", watchdogIterations+1)
+	}
+}
+
+func TestSchedulers_manyZeroBursts(t *testing.T) {
+	t.Parallel()
+	// More processes completing at the same instant than the watchdog allows iterations without time advancing.
+	processes := make([]Process, 2*watchdogIterations)
+	for i := range processes {
+		processes[i] = Process{ProcessID: int64(i + 1)}
+	}
+	processes = append(processes, Process{ProcessID: int64(len(processes) + 1), BurstDuration: 3})
+	for name, schedule := range map[string]Scheduler{
+		"FCFSSchedule":        FCFSSchedule,
+		"RRSchedule":          RRSchedule,
+		"MultiCoreRRSchedule": MultiCoreRRSchedule,
+	} {
+		if got := schedule(name, processes, DefaultOptions()); got.Err != nil {
+			t.Errorf("%v() error = %v, want every process to complete", name, got.Err)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// PriorityStats are the averages of the processes sharing one priority.
+type PriorityStats struct {
+	Priority      int64
+	Processes     int
+	AveWait       float64
+	AveTurnaround float64
+}
+
+// priorityStats averages the stats of each priority the processes have, highest priority first in the
+// options' priority order.
+func priorityStats(stats []ProcessStats, opts Options) []PriorityStats {
+	byPriority := make(map[int64]*PriorityStats)
+	for _, s := range stats {
+		p, ok := byPriority[s.Priority]
+		if !ok {
+			p = &PriorityStats{Priority: s.Priority}
+			byPriority[s.Priority] = p
+		}
+		p.Processes++
+		p.AveWait += float64(s.Wait)
+		p.AveTurnaround += float64(s.Turnaround)
+	}
+
+	priorities := make([]PriorityStats, 0, len(byPriority))
+	for _, p := range byPriority {
+		p.AveWait /= float64(p.Processes)
+		p.AveTurnaround /= float64(p.Processes)
+		priorities = append(priorities, *p)
+	}
+	sort.Slice(priorities, func(a, b int) bool {
+		return opts.higherPriority(priorities[a].Priority, priorities[b].Priority)
+	})
+
+	return priorities
+}
+
+func outputPriorityStats(w io.Writer, priorities []PriorityStats, opts Options) {
+	_, _ = fmt.Fprintln(w, "Priorities")
+	scale := float64(opts.ticksPerUnit())
+	table := newTable(w, opts)
+	table.SetHeader([]string{"Priority", "Processes", "Average wait", "Average turnaround"})
+	for _, p := range priorities {
+		table.Append([]string{
+			fmt.Sprint(p.Priority),
+			fmt.Sprint(p.Processes),
+			formatFloat(p.AveWait/scale, opts.Precision),
+			formatFloat(p.AveTurnaround/scale, opts.Precision),
+		})
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w)
+}
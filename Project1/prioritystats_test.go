@@ -0,0 +1,32 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_priorityStats(t *testing.T) {
+	t.Parallel()
+	// Equal bursts leave the priority scheduler to order by priority alone.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 0, BurstDuration: 3, Priority: 2},
+		{ProcessID: 4, ArrivalTime: 0, BurstDuration: 3, Priority: 1},
+	}
+	result := SJFPrioritySchedule("Priority", processes, DefaultOptions())
+	if !result.PerPriority {
+		t.Errorf("SJFPrioritySchedule() PerPriority = false, want true")
+	}
+	got := priorityStats(result.Stats, DefaultOptions())
+	want := []PriorityStats{
+		{Priority: 1, Processes: 2, AveWait: 1.5, AveTurnaround: 4.5},
+		{Priority: 2, Processes: 2, AveWait: 7.5, AveTurnaround: 10.5},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("priorityStats() = %+v, want %+v", got, want)
+	}
+	if got[0].AveWait >= got[1].AveWait {
+		t.Errorf("priorityStats() higher priority waited %v, not less than the lower's %v", got[0].AveWait, got[1].AveWait)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Roles of a process in the producer/consumer simulation.
+const (
+	RoleProducer = iota
+	RoleConsumer
+)
+
+// BufferLevel is how many items the bounded buffer holds from Time on.
+type BufferLevel struct {
+	Time  int64
+	Items int64
+}
+
+// producerConsumerScenario has two producers filling the buffer slightly faster than the consumer empties it,
+// until the second producer finishes.
+var producerConsumerScenario = struct {
+	processes []Process
+	roles     map[int64]int
+}{
+	processes: []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 4},
+		{ProcessID: 3, ArrivalTime: 0, BurstDuration: 10},
+	},
+	roles: map[int64]int{1: RoleProducer, 2: RoleProducer, 3: RoleConsumer},
+}
+
+// boundedBufferSchedule runs processes sharing a bounded buffer of the capacity, where every tick of a producer's
+// CPU time puts an item in the buffer and every tick of a consumer's takes one out. Each tick the first ready process
+// that can run does: a producer can't while the buffer is full, nor a consumer while it's empty, and is blocked on
+// the buffer until it can. It returns the gantt, the buffer's levels over time, and how long each process was
+// blocked on the buffer. If every unfinished process is blocked on the buffer the error is ErrNoProgress.
+func boundedBufferSchedule(
+	processes []Process,
+	roles map[int64]int,
+	capacity int64,
+	opts Options,
+) ([]TimeSlice, []BufferLevel, map[int64]int64, error) {
+	var (
+		items    int64
+		levels   = []BufferLevel{{Time: 0, Items: 0}}
+		blocked  = make(map[int64]int64, len(processes))
+		waiting  = make([]int64, 0)
+		lastPick int64
+	)
+	gantt, _, err := preemptiveSchedule(processes, 1, opts, func(ready []*runnable, time int64) int {
+		// Whatever couldn't run at the last pick was blocked until now.
+		for _, pid := range waiting {
+			blocked[pid] += time - lastPick
+		}
+		waiting, lastPick = waiting[:0], time
+
+		chosen := -1
+		for i, r := range ready {
+			producer := roles[r.ProcessID] == RoleProducer
+			if (producer && items >= capacity) || (!producer && items <= 0) {
+				waiting = append(waiting, r.ProcessID)
+				continue
+			}
+			if chosen < 0 {
+				chosen = i
+			}
+		}
+		if chosen < 0 {
+			return chosen
+		}
+		if roles[ready[chosen].ProcessID] == RoleProducer {
+			items++
+		} else {
+			items--
+		}
+		levels = append(levels, BufferLevel{Time: time + 1, Items: items})
+		return chosen
+	})
+
+	return gantt, levels, blocked, err
+}
+
+// outputProducerConsumerDemo runs the producer/consumer scenario with a buffer of opts.BufferSize items,
+// showing the gantt, the buffer's occupancy over time, and how long each process was blocked on it.
+func outputProducerConsumerDemo(w io.Writer, opts Options) {
+	scenario := producerConsumerScenario
+	outputTitle(w, fmt.Sprintf("Producer/consumer (buffer of %v)", opts.BufferSize))
+	gantt, levels, blocked, err := boundedBufferSchedule(scenario.processes, scenario.roles, opts.BufferSize, opts)
+	outputGantt(w, "Gantt schedule", gantt, opts)
+
+	producers, consumers := make([]string, 0), make([]string, 0)
+	for _, p := range scenario.processes {
+		if scenario.roles[p.ProcessID] == RoleProducer {
+			producers = append(producers, fmt.Sprint(p.ProcessID))
+		} else {
+			consumers = append(consumers, fmt.Sprint(p.ProcessID))
+		}
+	}
+	_, _ = fmt.Fprintf(w, "Producers: %v  Consumers: %v\n\n", strings.Join(producers, ", "), strings.Join(consumers, ", "))
+
+	_, _ = fmt.Fprintln(w, "Buffer occupancy")
+	for _, l := range levels {
+		_, _ = fmt.Fprintf(w, "%4v %v %v\n", l.Time, l.Items, repeat("#", int(l.Items)))
+	}
+	_, _ = fmt.Fprintln(w)
+
+	pids := make([]int64, 0, len(blocked))
+	for pid := range blocked {
+		pids = append(pids, pid)
+	}
+	sort.Slice(pids, func(a, b int) bool { return pids[a] < pids[b] })
+	for _, pid := range pids {
+		_, _ = fmt.Fprintf(w, "Process %v was blocked on the buffer for %v\n", pid, blocked[pid])
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(w, "Deadlock: %v\n", err)
+	}
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_boundedBufferSchedule(t *testing.T) {
+	t.Parallel()
+	// With room for a single item the producer and consumer can only take turns, each blocked while the other runs.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3},
+	}
+	roles := map[int64]int{1: RoleProducer, 2: RoleConsumer}
+	gantt, levels, blocked, err := boundedBufferSchedule(processes, roles, 1, DefaultOptions())
+	if err != nil {
+		t.Fatalf("boundedBufferSchedule() error = %v", err)
+	}
+	wantGantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 1},
+		{PID: 2, Start: 1, Stop: 2},
+		{PID: 1, Start: 2, Stop: 3},
+		{PID: 2, Start: 3, Stop: 4},
+		{PID: 1, Start: 4, Stop: 5},
+		{PID: 2, Start: 5, Stop: 6},
+	}
+	if !reflect.DeepEqual(gantt, wantGantt) {
+		t.Errorf("boundedBufferSchedule() gantt = %v, want %v", gantt, wantGantt)
+	}
+	wantLevels := []BufferLevel{{0, 0}, {1, 1}, {2, 0}, {3, 1}, {4, 0}, {5, 1}, {6, 0}}
+	if !reflect.DeepEqual(levels, wantLevels) {
+		t.Errorf("boundedBufferSchedule() levels = %v, want %v", levels, wantLevels)
+	}
+	// The producer finished before the consumer's last turn, so was blocked one tick less.
+	wantBlocked := map[int64]int64{1: 2, 2: 3}
+	if !reflect.DeepEqual(blocked, wantBlocked) {
+		t.Errorf("boundedBufferSchedule() blocked = %v, want %v", blocked, wantBlocked)
+	}
+}
+
+func Test_boundedBufferSchedule_deadlock(t *testing.T) {
+	t.Parallel()
+	// A consumer with nothing to consume can never run.
+	processes := []Process{{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2}}
+	_, _, _, err := boundedBufferSchedule(processes, map[int64]int{1: RoleConsumer}, 1, DefaultOptions())
+	if !errors.Is(err, ErrNoProgress) {
+		t.Errorf("boundedBufferSchedule() error = %v, want %v", err, ErrNoProgress)
+	}
+}
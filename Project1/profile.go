@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime/pprof"
+)
+
+// startCPUProfile starts writing a pprof CPU profile to the file at path, for -profile, returning the function
+// that stops the profile and closes the file.
+func startCPUProfile(path string) (func() error, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error creating CPU profile", err)
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("%w: error starting CPU profile", err)
+	}
+
+	return func() error {
+		pprof.StopCPUProfile()
+		return f.Close()
+	}, nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_startCPUProfile(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(1))
+	processes := make([]Process, 0)
+	for len(processes) < 2000 {
+		processes = append(processes, generateWorkload(rng)...)
+	}
+	for i := range processes {
+		processes[i].ProcessID = int64(i + 1)
+	}
+
+	path := filepath.Join(t.TempDir(), "cpu.prof")
+	stop, err := startCPUProfile(path)
+	if err != nil {
+		t.Fatalf("startCPUProfile() error = %v", err)
+	}
+	sortByArrival(processes, DefaultOptions())
+	for _, name := range defaultAlgorithms {
+		_ = algorithms[name].schedule(name, processes, DefaultOptions())
+	}
+	if err := stop(); err != nil {
+		t.Fatalf("startCPUProfile() stop error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil || info.Size() == 0 {
+		t.Errorf("startCPUProfile() wrote %v, error %v, want a non-empty profile", info, err)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// readyQueuePeak is the most processes ready to run but waiting for a core at once, and the first time the
+// queue was that long. A process is in the ready queue from its arrival to its completion, except while it runs
+// and while it's blocked on I/O, from its last CPU slice before an I/O request until the request completes.
+func readyQueuePeak(result SchedulerResult) (int, int64) {
+	type interval struct{ start, stop int64 }
+	busy := make(map[int64][]interval, len(result.Stats))
+	events := make([]int64, 0, 2*len(result.Gantt)+len(result.Stats))
+	for _, s := range result.Gantt {
+		busy[s.PID] = append(busy[s.PID], interval{s.Start, s.Stop})
+		events = append(events, s.Start, s.Stop)
+	}
+	for _, req := range result.IOGantt {
+		blocked := req.Start
+		for _, s := range result.Gantt {
+			if s.PID == req.PID && s.Stop <= req.Start && (blocked == req.Start || s.Stop > blocked) {
+				blocked = s.Stop
+			}
+		}
+		busy[req.PID] = append(busy[req.PID], interval{blocked, req.Stop})
+		events = append(events, req.Stop)
+	}
+	for _, s := range result.Stats {
+		events = append(events, s.ArrivalTime)
+	}
+	sort.Slice(events, func(a, b int) bool { return events[a] < events[b] })
+
+	var (
+		peak int
+		at   int64
+	)
+	for _, t := range events {
+		ready := 0
+		for _, s := range result.Stats {
+			if s.ArrivalTime > t || s.Completion <= t {
+				continue
+			}
+			waiting := true
+			for _, b := range busy[s.ProcessID] {
+				if b.start <= t && t < b.stop {
+					waiting = false
+					break
+				}
+			}
+			if waiting {
+				ready++
+			}
+		}
+		if ready > peak {
+			peak, at = ready, t
+		}
+	}
+
+	return peak, at
+}
+
+// outputReadyQueuePeak reports the longest the ready queue got, a proxy for contention: FCFS's convoys queue
+// everything behind a long job while RR keeps cycling the same queue.
+func outputReadyQueuePeak(w io.Writer, result SchedulerResult, opts Options) {
+	peak, at := readyQueuePeak(result)
+	_, _ = fmt.Fprintf(w, "Peak ready queue length: %v at time %v\n\n", peak, formatTime(at, opts.TimeScale))
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_readyQueuePeak(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		schedule Scheduler
+		procs    []Process
+		want     int
+		wantAt   int64
+	}{
+		{
+			name:     "simultaneous arrivals",
+			schedule: FCFSSchedule,
+			procs: []Process{
+				{ProcessID: 1, ArrivalTime: 1, BurstDuration: 3},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+				{ProcessID: 3, ArrivalTime: 1, BurstDuration: 3},
+				{ProcessID: 4, ArrivalTime: 1, BurstDuration: 3},
+			},
+			want:   3,
+			wantAt: 1,
+		},
+		{
+			name:     "convoy",
+			schedule: FCFSSchedule,
+			procs: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 10},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1},
+				{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1},
+				{ProcessID: 4, ArrivalTime: 3, BurstDuration: 1},
+			},
+			want:   3,
+			wantAt: 3,
+		},
+		{
+			name:     "no contention",
+			schedule: FCFSSchedule,
+			procs: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: 2, ArrivalTime: 5, BurstDuration: 2},
+			},
+			want:   0,
+			wantAt: 0,
+		},
+		{
+			name:     "blocked on I/O",
+			schedule: FCFSIOSchedule,
+			// Process 1 is blocked rather than ready when process 3 arrives to wait behind process 2.
+			procs: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, IO: []IORequest{{At: 1, Duration: 5}}},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1},
+			},
+			want:   1,
+			wantAt: 0,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, at := readyQueuePeak(tt.schedule(tt.name, tt.procs, DefaultOptions()))
+			if got != tt.want || at != tt.wantAt {
+				t.Errorf("readyQueuePeak() = %v at %v, want %v at %v", got, at, tt.want, tt.wantAt)
+			}
+		})
+	}
+}
+
+func Test_outputReadyQueuePeak(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+	}
+	var w bytes.Buffer
+	outputReadyQueuePeak(&w, FCFSSchedule("FCFS", processes, DefaultOptions()), DefaultOptions())
+	if want := "Peak ready queue length: 1 at time 1\n\n"; w.String() != want {
+		t.Errorf("outputReadyQueuePeak() = %q, want %q", w.String(), want)
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import "container/heap"
+
+// ReadyQueue is a ready queue of indexes into a slice of processes, kept as a min-heap by less so its front
+// is always the process that should run next. Indexes less can't order come out in the order they were pushed,
+// the same as a stable sort of the queue would give.
+type ReadyQueue struct {
+	h readyHeap
+}
+
+// NewReadyQueue returns an empty ready queue ordered by less, which reports whether index a should run before b.
+func NewReadyQueue(less func(a, b int) bool) *ReadyQueue {
+	return &ReadyQueue{h: readyHeap{less: less}}
+}
+
+// Push queues the index, O(log n).
+func (q *ReadyQueue) Push(i int) {
+	heap.Push(&q.h, queued{index: i, seq: q.h.pushed})
+	q.h.pushed++
+}
+
+// Pop removes and returns the front index, O(log n). The queue mustn't be empty.
+func (q *ReadyQueue) Pop() int {
+	return heap.Pop(&q.h).(queued).index
+}
+
+// Peek returns the front index without removing it. The queue mustn't be empty.
+func (q *ReadyQueue) Peek() int {
+	return q.h.items[0].index
+}
+
+// Len is how many indexes are queued.
+func (q *ReadyQueue) Len() int {
+	return len(q.h.items)
+}
+
+// queued is an index in the heap with when it was pushed, to break ties.
+type queued struct {
+	index int
+	seq   int
+}
+
+// readyHeap implements heap.Interface for ReadyQueue.
+type readyHeap struct {
+	items  []queued
+	less   func(a, b int) bool
+	pushed int
+}
+
+func (h *readyHeap) Len() int { return len(h.items) }
+
+func (h *readyHeap) Less(i, j int) bool {
+	a, b := h.items[i], h.items[j]
+	switch {
+	case h.less(a.index, b.index):
+		return true
+	case h.less(b.index, a.index):
+		return false
+	default:
+		return a.seq < b.seq
+	}
+}
+
+func (h *readyHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+func (h *readyHeap) Push(x any) { h.items = append(h.items, x.(queued)) }
+
+func (h *readyHeap) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+
+	return last
+}
+
+// queuedPick returns a pick that keeps the engine's ready processes in a ReadyQueue ordered by less, for picks
+// whose order doesn't change while a process waits, like a deadline or a fixed priority. Each process joining
+// ready is pushed once and processes that left are dropped, so ties less can't order go to whichever joined
+// ready first, the same as scanning ready for the first best process.
+func queuedPick(less func(a, b *runnable) bool) func(ready []*runnable, time int64) int {
+	var queued []*runnable
+	ids := make(map[*runnable]int)
+	q := NewReadyQueue(func(a, b int) bool { return less(queued[a], queued[b]) })
+
+	return func(ready []*runnable, _ int64) int {
+		at := make(map[*runnable]int, len(ready))
+		for i, r := range ready {
+			at[r] = i
+		}
+		// A process that left ready and came back, say from I/O, rejoins under a new id, as if it were new.
+		for r := range ids {
+			if _, ok := at[r]; !ok {
+				delete(ids, r)
+			}
+		}
+		for _, r := range ready {
+			if _, ok := ids[r]; !ok {
+				ids[r] = len(queued)
+				queued = append(queued, r)
+				q.Push(ids[r])
+			}
+		}
+		for q.Len() > 0 {
+			if id, ok := ids[queued[q.Peek()]]; ok && id == q.Peek() {
+				break
+			}
+			q.Pop()
+		}
+		if q.Len() == 0 {
+			return -1
+		}
+
+		return at[queued[q.Peek()]]
+	}
+}
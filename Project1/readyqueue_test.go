@@ -0,0 +1,168 @@
+package main
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestReadyQueue(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5, Priority: 2, Deadline: 20},
+		{ProcessID: 2, BurstDuration: 3, Priority: 1, Deadline: 10},
+		{ProcessID: 3, BurstDuration: 5, Priority: 1, Deadline: 30},
+		{ProcessID: 4, BurstDuration: 1, Priority: 3, Deadline: 10},
+	}
+	tests := []struct {
+		name string
+		less func(a, b int) bool
+		want []int64
+	}{
+		{
+			name: "shortest burst, ties in push order",
+			less: func(a, b int) bool { return processes[a].BurstDuration < processes[b].BurstDuration },
+			want: []int64{4, 2, 1, 3},
+		},
+		{
+			name: "burst then priority",
+			less: func(a, b int) bool {
+				if processes[a].BurstDuration == processes[b].BurstDuration {
+					return processes[a].Priority < processes[b].Priority
+				}
+				return processes[a].BurstDuration < processes[b].BurstDuration
+			},
+			want: []int64{4, 2, 3, 1},
+		},
+		{
+			name: "earliest deadline, ties in push order",
+			less: func(a, b int) bool { return processes[a].Deadline < processes[b].Deadline },
+			want: []int64{2, 4, 1, 3},
+		},
+		{
+			name: "nothing orders, so push order",
+			less: func(a, b int) bool { return false },
+			want: []int64{1, 2, 3, 4},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			q := NewReadyQueue(tt.less)
+			for i := range processes {
+				q.Push(i)
+			}
+			got := make([]int64, 0, len(processes))
+			for q.Len() > 0 {
+				peeked := q.Peek()
+				popped := q.Pop()
+				if peeked != popped {
+					t.Fatalf("Peek() = %v but Pop() = %v", peeked, popped)
+				}
+				got = append(got, processes[popped].ProcessID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadyQueue order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadyQueue_interleaved(t *testing.T) {
+	t.Parallel()
+	bursts := []int64{4, 9, 1, 3}
+	q := NewReadyQueue(func(a, b int) bool { return bursts[a] < bursts[b] })
+	q.Push(0)
+	q.Push(1)
+	if got := q.Pop(); got != 0 {
+		t.Errorf("Pop() = %v, want 0", got)
+	}
+	q.Push(2)
+	q.Push(3)
+	q.Push(0)
+	got := make([]int, 0)
+	for q.Len() > 0 {
+		got = append(got, q.Pop())
+	}
+	if want := []int{2, 3, 0, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadyQueue order = %v, want %v", got, want)
+	}
+}
+
+func Test_queuedPick(t *testing.T) {
+	t.Parallel()
+	// Equal deadlines and priorities, with processes leaving for I/O and coming back, so ties are decided by
+	// the tie-break and by when each process joined ready.
+	processes := []Process{
+		{ProcessID: 3, BurstDuration: 4, Priority: 1, Deadline: 10, IO: []IORequest{{At: 1, Duration: 2}}},
+		{ProcessID: 1, BurstDuration: 3, Priority: 2, Deadline: 10},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 1, Deadline: 0},
+		{ProcessID: 5, ArrivalTime: 2, BurstDuration: 3, Priority: 1, Deadline: 10, IO: []IORequest{{At: 2, Duration: 1}}},
+		{ProcessID: 4, ArrivalTime: 3, BurstDuration: 1, Priority: 2, Deadline: 8},
+	}
+	picks := []struct {
+		name string
+		pick func(opts Options) func(ready []*runnable, time int64) int
+		less func(opts Options) func(a, b *runnable) bool
+	}{
+		{
+			name: "earliest deadline",
+			pick: pickEarliestDeadline,
+			less: func(opts Options) func(a, b *runnable) bool {
+				return func(a, b *runnable) bool {
+					da, db := a.Deadline, b.Deadline
+					if da == 0 {
+						da = math.MaxInt64
+					}
+					if db == 0 {
+						db = math.MaxInt64
+					}
+					return da < db || da == db && opts.before(a.Process, b.Process, false)
+				}
+			},
+		},
+		{
+			name: "highest priority",
+			pick: pickHighestPriority,
+			less: func(opts Options) func(a, b *runnable) bool {
+				return func(a, b *runnable) bool {
+					return opts.higherPriority(a.Priority, b.Priority) ||
+						a.Priority == b.Priority && opts.before(a.Process, b.Process, false)
+				}
+			},
+		},
+	}
+	for _, p := range picks {
+		for _, tieBreak := range []string{TieBreakArrival, TieBreakPID, TieBreakFIFO} {
+			p, tieBreak := p, tieBreak
+			t.Run(p.name+" by "+tieBreak, func(t *testing.T) {
+				t.Parallel()
+				opts := DefaultOptions()
+				opts.TieBreak = tieBreak
+				less := p.less(opts)
+				// The first best process scanning ready, which the queue must always agree with.
+				scan := func(ready []*runnable, _ int64) int {
+					best := 0
+					for i := 1; i < len(ready); i++ {
+						if less(ready[i], ready[best]) {
+							best = i
+						}
+					}
+					return best
+				}
+				want, wantIO, err := preemptiveSchedule(processes, 0, opts, scan)
+				if err != nil {
+					t.Fatalf("scanning unexpected error: %v", err)
+				}
+				got, gotIO, err := preemptiveSchedule(processes, 0, opts, p.pick(opts))
+				if err != nil {
+					t.Fatalf("queued unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(got, want) || !reflect.DeepEqual(gotIO, wantIO) {
+					t.Errorf("queued gantt = %v %v, want %v %v from scanning", got, gotIO, want, wantIO)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+// EDFSchedule preemptively schedules the ready process with the earliest deadline, processes without a deadline
+// only run when nothing with a deadline is ready. Ties go by opts.TieBreak. With opts.AbortAtDeadline a process
+// still running at its deadline is aborted, leaving the CPU to the rest, and left out of the stats.
+func EDFSchedule(title string, inputProcesses []Process, opts Options) SchedulerResult {
+	e := newEngine(inputProcesses, opts)
+	e.AbortAtDeadline = opts.AbortAtDeadline
+	err := e.run(0, pickEarliestDeadline(opts), opts.until())
+
+	result := calculateCompletionStats(title, inputProcesses, e.Gantt)
+	result.IOGantt = e.IOGantt
+	result.Err = err
+	if len(e.Aborted) > 0 {
+		result = result.withAborted(e.Aborted)
+	}
+
+	return result
+}
+
+// pickEarliestDeadline picks the ready process with the earliest deadline, ties going by opts.TieBreak.
+func pickEarliestDeadline(opts Options) func(ready []*runnable, time int64) int {
+	deadline := func(p Process) int64 {
+		if p.Deadline == 0 {
+			return math.MaxInt64
+		}
+		return p.Deadline
+	}
+
+	return queuedPick(func(a, b *runnable) bool {
+		if deadline(a.Process) != deadline(b.Process) {
+			return deadline(a.Process) < deadline(b.Process)
+		}
+		return opts.before(a.Process, b.Process, false)
+	})
+}
+
+// hasDeadlines reports if any of the processes has a deadline.
+func hasDeadlines(processes []Process) bool {
+	for i := range processes {
+		if processes[i].Deadline != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RealTimeMetrics aggregates how well a schedule met the processes' deadlines.
+type RealTimeMetrics struct {
+	// Missed is the count of processes completing after their deadline.
+	Missed int
+	// MaxLateness is the largest completion minus deadline, negative if every process finished early.
+	MaxLateness int64
+	// TotalTardiness sums the lateness of only the late processes.
+	TotalTardiness int64
+	// Completed is the count of processes completing, with a deadline or not.
+	Completed int
+}
+
+// goodput is the throughput counting only the completions that didn't miss their deadline, the useful work
+// delivered, so it falls below throughput as an overloaded scheduler misses deadlines.
+func (m RealTimeMetrics) goodput(throughput float64) float64 {
+	if m.Completed == 0 {
+		return 0
+	}
+
+	return throughput * float64(m.Completed-m.Missed) / float64(m.Completed)
+}
+
+// realTimeMetrics measures deadline misses over the processes that have a deadline.
+func realTimeMetrics(stats []ProcessStats) RealTimeMetrics {
+	var (
+		m     = RealTimeMetrics{Completed: len(stats)}
+		first = true
+	)
+	for i := range stats {
+		if stats[i].Deadline == 0 {
+			continue
+		}
+		lateness := stats[i].Completion - stats[i].Deadline
+		if first || lateness > m.MaxLateness {
+			m.MaxLateness = lateness
+			first = false
+		}
+		if lateness > 0 {
+			m.Missed++
+			m.TotalTardiness += lateness
+		}
+	}
+
+	return m
+}
+
+// outputRealTimeMetrics reports the deadline metrics, with the throughput in units of time beside the goodput.
+func outputRealTimeMetrics(w io.Writer, m RealTimeMetrics, throughput float64, opts Options) {
+	scale := opts.TimeScale
+	_, _ = fmt.Fprintln(w, "Real-time metrics")
+	_, _ = fmt.Fprintf(w, "Deadlines missed: %v\n", m.Missed)
+	_, _ = fmt.Fprintf(w, "Max lateness:     %v\n", formatTime(m.MaxLateness, scale))
+	_, _ = fmt.Fprintf(w, "Total tardiness:  %v\n", formatTime(m.TotalTardiness, scale))
+	_, _ = fmt.Fprintf(w, "Throughput:       %v/t\n", formatFloat(throughput, opts.Precision))
+	_, _ = fmt.Fprintf(w, "Goodput:          %v/t\n", formatFloat(m.goodput(throughput), opts.Precision))
+	_, _ = fmt.Fprintln(w)
+}
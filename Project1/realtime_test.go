@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEDFSchedule(t *testing.T) {
+	t.Parallel()
+	// Overloaded: 9 units of work all due by time 6.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Deadline: 4},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3, Deadline: 5},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2, Deadline: 6},
+	}
+	got := EDFSchedule("Earliest-deadline-first", processes, DefaultOptions())
+
+	wantGantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 4},
+		{PID: 2, Start: 4, Stop: 7},
+		{PID: 3, Start: 7, Stop: 9},
+	}
+	if !reflect.DeepEqual(got.Gantt, wantGantt) {
+		t.Fatalf("EDFSchedule() gantt = %v, want %v", got.Gantt, wantGantt)
+	}
+
+	want := RealTimeMetrics{Missed: 2, MaxLateness: 3, TotalTardiness: 5, Completed: 3}
+	if m := realTimeMetrics(got.Stats); m != want {
+		t.Errorf("realTimeMetrics() = %+v, want %+v", m, want)
+	}
+
+	var w bytes.Buffer
+	outputResult(&w, got, DefaultOptions())
+	for _, want := range []string{"Real-time metrics", "Deadlines missed: 2", "Max lateness:     3",
+		"Throughput:       0.33/t", "Goodput:          0.11/t"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputResult() missing %q: %v", want, w.String())
+		}
+	}
+}
+
+func TestEDFSchedule_preempts(t *testing.T) {
+	t.Parallel()
+	got := EDFSchedule("Earliest-deadline-first", []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 2, BurstDuration: 2, Deadline: 5},
+	}, DefaultOptions())
+
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 2, Start: 2, Stop: 4},
+		{PID: 1, Start: 4, Stop: 7},
+	}
+	if !reflect.DeepEqual(got.Gantt, want) {
+		t.Errorf("EDFSchedule() gantt = %v, want %v", got.Gantt, want)
+	}
+}
+
+func TestRealTimeMetrics_goodput(t *testing.T) {
+	t.Parallel()
+	// Overloaded: 8 units of work due by time 4, so at most one process can meet its deadline.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Deadline: 2},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2, Deadline: 3},
+		{ProcessID: 3, ArrivalTime: 0, BurstDuration: 2, Deadline: 4},
+		{ProcessID: 4, ArrivalTime: 0, BurstDuration: 2, Deadline: 4},
+	}
+	got := EDFSchedule("Earliest-deadline-first", processes, DefaultOptions())
+	m := realTimeMetrics(got.Stats)
+	// 4 completions in 8 units of time, of which only the first meets its deadline.
+	if got.Throughput != 0.5 {
+		t.Fatalf("EDFSchedule() throughput = %v, want 0.5", got.Throughput)
+	}
+	if goodput := m.goodput(got.Throughput); goodput != 0.125 {
+		t.Errorf("goodput() = %v, want 0.125", goodput)
+	}
+}
+
+func Test_outputResult_noDeadlines(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	outputResult(&w, FCFSSchedule("FCFS", []Process{{ProcessID: 1, BurstDuration: 2}}, DefaultOptions()), DefaultOptions())
+	if strings.Contains(w.String(), "Real-time metrics") {
+		t.Errorf("outputResult() shouldn't show real-time metrics without deadlines: %v", w.String())
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// update regenerates the regression harness's expected results instead of checking them, after a deliberate
+// change to a scheduler: go test -run TestRegression -update
+var update = flag.Bool("update", false, "regenerate the expected results under testdata/regression")
+
+// regressionTolerance is how far averages may drift from the expected results, only floating point noise.
+const regressionTolerance = 1e-9
+
+// TestRegression runs every algorithm on every workload under testdata/regression, comparing each result
+// with the workload's .jsonl of expected results as written by -format json.
+func TestRegression(t *testing.T) {
+	t.Parallel()
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "regression", "*.csv"))
+	if err != nil || len(fixtures) == 0 {
+		t.Fatalf("no regression fixtures: %v", err)
+	}
+	for _, fixture := range fixtures {
+		fixture := fixture
+		name := strings.TrimSuffix(filepath.Base(fixture), ".csv")
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			f, err := os.Open(fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			processes, scale, err := loadProcesses(f)
+			if err != nil {
+				t.Fatalf("loadProcesses() error = %v", err)
+			}
+			opts := DefaultOptions().withTimeScale(scale)
+			sortByArrival(processes, opts)
+
+			results := make([]SchedulerResult, 0, len(algorithms))
+			for _, algorithm := range algorithmNames() {
+				s := algorithms[algorithm]
+				results = append(results, s.schedule(s.title, processes, opts))
+			}
+
+			golden := strings.TrimSuffix(fixture, ".csv") + ".jsonl"
+			if *update {
+				var w bytes.Buffer
+				for _, result := range results {
+					outputJSON(&w, result, opts)
+				}
+				if err := os.WriteFile(golden, w.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			g, err := os.Open(golden)
+			if err != nil {
+				t.Fatalf("%v: regenerate the expected results with -update", err)
+			}
+			defer g.Close()
+			want, err := readResultRecords(g)
+			if err != nil {
+				t.Fatalf("readResultRecords() error = %v", err)
+			}
+			if len(want) != len(results) {
+				t.Errorf("%v has %v results, want one for each of the %v algorithms", golden, len(want), len(results))
+			}
+			got := make(map[string]ResultRecord, len(results))
+			for _, result := range results {
+				got[result.Title] = resultRecord(result, opts)
+			}
+			for _, w := range want {
+				record, ok := got[w.Title]
+				if !ok {
+					t.Errorf("%v wasn't run", w.Title)
+					continue
+				}
+				for _, diff := range compareResults(w, record, regressionTolerance) {
+					t.Error(diff)
+				}
+			}
+		})
+	}
+}
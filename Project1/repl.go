@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const replHelp = `Commands:
+  add <id> <burst> <arrival> [priority]  add a process
+  remove <id>                            remove a process
+  list                                   list the processes
+  run [algo]                             schedule the processes, with the default schedulers without an algo
+  clear                                  remove every process
+  help                                   show this help
+  quit                                   leave
+`
+
+// replSession is the workload being built up in the REPL.
+type replSession struct {
+	w         io.Writer
+	opts      Options
+	processes []Process
+	// added numbers the processes in the order they were added, standing in for their line in a file.
+	added int
+}
+
+// repl reads commands from r, building a workload in memory and scheduling it, until r ends or a quit command.
+// A malformed command is reported and the session carries on.
+func repl(r io.Reader, w io.Writer, opts Options) error {
+	s := &replSession{w: w, opts: opts}
+	_, _ = fmt.Fprint(w, "Type help for the commands.\n> ")
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 0 {
+			if fields[0] == "quit" || fields[0] == "exit" {
+				return nil
+			}
+			if err := s.command(fields[0], fields[1:]); err != nil {
+				_, _ = fmt.Fprintf(w, "error: %v\n", err)
+			}
+		}
+		_, _ = fmt.Fprint(w, "> ")
+	}
+
+	return scanner.Err()
+}
+
+func (s *replSession) command(name string, args []string) error {
+	switch name {
+	case "add":
+		return s.add(args)
+	case "remove":
+		return s.remove(args)
+	case "list":
+		s.list()
+	case "run":
+		return s.run(args)
+	case "clear":
+		s.processes = nil
+	case "help":
+		_, _ = fmt.Fprint(s.w, replHelp)
+	default:
+		return fmt.Errorf("%w: unknown command %q, type help for the commands", ErrInvalidArgs, name)
+	}
+
+	return nil
+}
+
+func (s *replSession) add(args []string) error {
+	if len(args) < 3 || len(args) > 4 {
+		return fmt.Errorf("%w: usage: add <id> <burst> <arrival> [priority]", ErrInvalidArgs)
+	}
+	values := make([]int64, 4)
+	for i, arg := range args {
+		v, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: %q isn't a whole number", ErrInvalidArgs, arg)
+		}
+		values[i] = v
+	}
+	p := Process{ProcessID: values[0], BurstDuration: values[1], ArrivalTime: values[2], Priority: values[3]}
+	if p.BurstDuration <= 0 {
+		return fmt.Errorf("%w: process %v needs a positive burst", ErrInvalidArgs, p.ProcessID)
+	}
+	if p.ArrivalTime < 0 {
+		return fmt.Errorf("%w: process %v can't arrive before 0", ErrInvalidArgs, p.ProcessID)
+	}
+	if s.find(p.ProcessID) >= 0 {
+		return fmt.Errorf("%w: process %v already exists", ErrInvalidArgs, p.ProcessID)
+	}
+	s.added++
+	p.Line = s.added
+	s.processes = append(s.processes, p)
+
+	return nil
+}
+
+func (s *replSession) remove(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: usage: remove <id>", ErrInvalidArgs)
+	}
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %q isn't a whole number", ErrInvalidArgs, args[0])
+	}
+	i := s.find(id)
+	if i < 0 {
+		return fmt.Errorf("%w: no process %v", ErrInvalidArgs, id)
+	}
+	s.processes = append(s.processes[:i], s.processes[i+1:]...)
+
+	return nil
+}
+
+// find is the index of the process with the ID, or -1 if there isn't one.
+func (s *replSession) find(id int64) int {
+	for i := range s.processes {
+		if s.processes[i].ProcessID == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (s *replSession) list() {
+	table := newTable(s.w, s.opts)
+	table.SetHeader([]string{"ID", "Burst", "Arrival", "Priority"})
+	for _, p := range s.processes {
+		table.Append([]string{
+			fmt.Sprint(p.ProcessID),
+			fmt.Sprint(p.BurstDuration),
+			fmt.Sprint(p.ArrivalTime),
+			fmt.Sprint(p.Priority),
+		})
+	}
+	table.Render()
+}
+
+func (s *replSession) run(args []string) error {
+	if len(s.processes) == 0 {
+		return fmt.Errorf("%w: no processes to schedule, add some first", ErrInvalidArgs)
+	}
+	processes := make([]Process, len(s.processes))
+	copy(processes, s.processes)
+	sortByArrival(processes, s.opts)
+
+	opts := s.opts
+	if len(args) > 0 {
+		for _, name := range args {
+			if _, ok := algorithms[name]; !ok {
+				return fmt.Errorf("%w: unknown algorithm %q, pick from: %v",
+					ErrInvalidArgs, name, strings.Join(algorithmNames(), ", "))
+			}
+		}
+		opts.Algorithms = args
+	}
+	for _, scheduler := range selectSchedulers(opts, processes) {
+		result := scheduler.schedule(scheduler.title, processes, opts)
+		if result.Err != nil {
+			return fmt.Errorf("%v: %w", scheduler.title, result.Err)
+		}
+		outputResult(s.w, result, opts)
+	}
+
+	return nil
+}
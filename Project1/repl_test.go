@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_repl(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		script []string
+		want   []string
+	}{
+		{
+			name:   "add and run",
+			script: []string{"add 1 3 0", "add 2 2 1 4", "run fcfs"},
+			want:   []string{"First-come, first-serve", "|   1   |   2   |\n0\t3\t5\n"},
+		},
+		{
+			name:   "run sorts by arrival",
+			script: []string{"add 2 2 1", "add 1 3 0", "run fcfs"},
+			want:   []string{"|   1   |   2   |\n0\t3\t5\n"},
+		},
+		{
+			name:   "remove",
+			script: []string{"add 1 3 0", "add 2 2 0", "remove 1", "run fcfs"},
+			want:   []string{"|   2   |\n0\t2\n"},
+		},
+		{
+			name:   "clear",
+			script: []string{"add 1 3 0", "clear", "run"},
+			want:   []string{"error: invalid args: no processes to schedule"},
+		},
+		{
+			name:   "list",
+			script: []string{"add 7 3 0 2", "list"},
+			want:   []string{"|  7 |     3 |       0 |        2 |"},
+		},
+		{
+			name:   "malformed commands",
+			script: []string{"add 1", "add 1 x 0", "add 1 3 0", "add 1 2 0", "remove 9", "run lottery", "frobnicate"},
+			want: []string{
+				"error: invalid args: usage: add",
+				`error: invalid args: "x" isn't a whole number`,
+				"error: invalid args: process 1 already exists",
+				"error: invalid args: no process 9",
+				`error: invalid args: unknown algorithm "lottery"`,
+				`error: invalid args: unknown command "frobnicate"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			if err := repl(strings.NewReader(strings.Join(tt.script, "\n")), &w, DefaultOptions()); err != nil {
+				t.Fatalf("repl() unexpected error: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(w.String(), want) {
+					t.Errorf("repl() output should contain %q, got:\n%v", want, w.String())
+				}
+			}
+		})
+	}
+}
+
+func Test_repl_quit(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	if err := repl(strings.NewReader("quit\nadd 1 3 0\nlist"), &w, DefaultOptions()); err != nil {
+		t.Fatalf("repl() unexpected error: %v", err)
+	}
+	if strings.Contains(w.String(), "ID") {
+		t.Errorf("repl() should stop reading at quit, got:\n%v", w.String())
+	}
+}
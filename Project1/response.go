@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// responseTimes is how long each process waited for its first dispatch, in the order of the result's stats.
+func responseTimes(result SchedulerResult) []int64 {
+	first := make(map[int64]int64, len(result.Stats))
+	for _, s := range result.Gantt {
+		if start, ok := first[s.PID]; !ok || s.Start < start {
+			first[s.PID] = s.Start
+		}
+	}
+	times := make([]int64, len(result.Stats))
+	for i, s := range result.Stats {
+		times[i] = first[s.ProcessID] - s.ArrivalTime
+	}
+
+	return times
+}
+
+// averageResponse is the mean of responseTimes.
+func averageResponse(result SchedulerResult) float64 {
+	if len(result.Stats) == 0 {
+		return 0
+	}
+	var total float64
+	for _, r := range responseTimes(result) {
+		total += float64(r)
+	}
+
+	return total / float64(len(result.Stats))
+}
+
+// outputResponse puts average response time beside average waiting time, which preemption pulls apart:
+// a process preempted after its first dispatch keeps waiting without its response time growing.
+func outputResponse(w io.Writer, result SchedulerResult, opts Options) {
+	scale := float64(opts.ticksPerUnit())
+	_, _ = fmt.Fprintf(w, "Average response time: %v  Average waiting time: %v\n\n",
+		formatFloat(averageResponse(result)/scale, opts.Precision),
+		formatFloat(result.AveWait/scale, opts.Precision))
+}
+
+// weightedTurnaround is the average turnaround weighted by burst, each unit of work counting equally rather
+// than each process, so it reflects where the work is.
+func weightedTurnaround(result SchedulerResult) float64 {
+	var weighted, bursts float64
+	for _, s := range result.Stats {
+		weighted += float64(s.BurstDuration) * float64(s.Turnaround)
+		bursts += float64(s.BurstDuration)
+	}
+	if bursts == 0 {
+		return 0
+	}
+
+	return weighted / bursts
+}
+
+// outputWeightedTurnaround puts the burst-weighted average turnaround beside the plain average, which one long
+// job pulls apart: it dominates the weighted average but counts once in the plain one.
+func outputWeightedTurnaround(w io.Writer, result SchedulerResult, opts Options) {
+	scale := float64(opts.ticksPerUnit())
+	_, _ = fmt.Fprintf(w, "Burst-weighted average turnaround: %v  Average turnaround: %v\n\n",
+		formatFloat(weightedTurnaround(result)/scale, opts.Precision),
+		formatFloat(result.AveTurnaround/scale, opts.Precision))
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_responseTimes(t *testing.T) {
+	t.Parallel()
+	// The short job preempts the long one as soon as it arrives, so both respond at once,
+	// but the long job then waits while preempted.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 8},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+	}
+	result := SJFSchedule("Shortest-remaining-time-first", processes, DefaultOptions())
+
+	if got, want := responseTimes(result), []int64{0, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("responseTimes() = %v, want %v", got, want)
+	}
+	if got := averageResponse(result); got != 0 {
+		t.Errorf("averageResponse() = %v, want 0", got)
+	}
+	if result.AveWait <= averageResponse(result) {
+		t.Errorf("average wait %v should be more than the average response %v", result.AveWait, averageResponse(result))
+	}
+}
+
+func Test_weightedTurnaround(t *testing.T) {
+	t.Parallel()
+	// Two short jobs turn around at once, but the long job is nearly all of the work.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 1},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 98},
+	}
+	result := FCFSSchedule("FCFS", processes, DefaultOptions())
+	if got, want := weightedTurnaround(result), (1.0+1+98*98)/100; got != want {
+		t.Errorf("weightedTurnaround() = %v, want %v", got, want)
+	}
+	if got, want := result.AveTurnaround, 100.0/3; got != want {
+		t.Errorf("average turnaround = %v, want %v", got, want)
+	}
+
+	var w bytes.Buffer
+	outputWeightedTurnaround(&w, result, DefaultOptions())
+	if want := "Burst-weighted average turnaround: 96.06  Average turnaround: 33.33"; !strings.Contains(w.String(), want) {
+		t.Errorf("outputWeightedTurnaround() = %q, want %q", w.String(), want)
+	}
+}
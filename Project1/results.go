@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// ResultRecord is a SchedulerResult as written by -format json and read back by -validate-against,
+// with its times in units of time rather than ticks.
+type ResultRecord struct {
+	Title             string          `json:"title"`
+	Gantt             []SliceRecord   `json:"gantt"`
+	Processes         []ProcessRecord `json:"processes"`
+	AverageWait       float64         `json:"average_wait"`
+	AverageTurnaround float64         `json:"average_turnaround"`
+	Throughput        float64         `json:"throughput"`
+}
+
+// SliceRecord is a TimeSlice of a ResultRecord.
+type SliceRecord struct {
+	PID   int64   `json:"pid"`
+	Start float64 `json:"start"`
+	Stop  float64 `json:"stop"`
+	Core  int     `json:"core,omitempty"`
+}
+
+// ProcessRecord is the ProcessStats of a ResultRecord.
+type ProcessRecord struct {
+	ID         int64   `json:"id"`
+	Wait       float64 `json:"wait"`
+	Turnaround float64 `json:"turnaround"`
+	Completion float64 `json:"completion"`
+}
+
+// resultRecord converts the result's ticks to units of time at the options' time scale.
+func resultRecord(result SchedulerResult, opts Options) ResultRecord {
+	scale := float64(opts.ticksPerUnit())
+	wait, turnaround, throughput := result.averages(opts.TimeScale)
+	record := ResultRecord{
+		Title:             result.Title,
+		Gantt:             make([]SliceRecord, len(result.Gantt)),
+		Processes:         make([]ProcessRecord, len(result.Stats)),
+		AverageWait:       wait,
+		AverageTurnaround: turnaround,
+		Throughput:        throughput,
+	}
+	for i, s := range result.Gantt {
+		record.Gantt[i] = SliceRecord{PID: s.PID, Start: float64(s.Start) / scale, Stop: float64(s.Stop) / scale, Core: s.Core}
+	}
+	for i, s := range result.Stats {
+		record.Processes[i] = ProcessRecord{
+			ID:         s.ProcessID,
+			Wait:       float64(s.Wait) / scale,
+			Turnaround: float64(s.Turnaround) / scale,
+			Completion: float64(s.Completion) / scale,
+		}
+	}
+
+	return record
+}
+
+// outputJSON writes the result as a single line of JSON, so the results of several schedulers are JSON Lines.
+func outputJSON(w io.Writer, result SchedulerResult, opts Options) {
+	if err := json.NewEncoder(w).Encode(resultRecord(result, opts)); err != nil {
+		_, _ = fmt.Fprintf(w, "error encoding %v: %v\n", result.Title, err)
+	}
+}
+
+// MetricsRecord is the aggregate metrics of a SchedulerResult as written by -metrics-only, with its times
+// in units of time rather than ticks.
+type MetricsRecord struct {
+	Processes         int     `json:"processes"`
+	AverageWait       float64 `json:"average_wait"`
+	AverageTurnaround float64 `json:"average_turnaround"`
+	AverageResponse   float64 `json:"average_response"`
+	Throughput        float64 `json:"throughput"`
+	Makespan          float64 `json:"makespan"`
+	// The response ratios at dispatch, only for HRRN.
+	AverageResponseRatio float64 `json:"average_response_ratio,omitempty"`
+	MaxResponseRatio     float64 `json:"max_response_ratio,omitempty"`
+}
+
+// metricsRecord converts the result's aggregate metrics to units of time at the options' time scale.
+func metricsRecord(result SchedulerResult, opts Options) MetricsRecord {
+	scale := float64(opts.ticksPerUnit())
+	wait, turnaround, throughput := result.averages(opts.TimeScale)
+	ratio, highest := responseRatioSummary(result.ResponseRatios)
+
+	return MetricsRecord{
+		Processes:            len(result.Stats),
+		AverageWait:          wait,
+		AverageTurnaround:    turnaround,
+		AverageResponse:      averageResponse(result) / scale,
+		Throughput:           throughput,
+		Makespan:             float64(ganttMakespan(result.Gantt)) / scale,
+		AverageResponseRatio: ratio,
+		MaxResponseRatio:     highest.Ratio,
+	}
+}
+
+// outputMetrics writes the metrics of every result as a single JSON object, keyed by the schedulers' titles.
+func outputMetrics(w io.Writer, results []SchedulerResult, opts Options) error {
+	metrics := make(map[string]MetricsRecord, len(results))
+	for _, result := range results {
+		metrics[result.Title] = metricsRecord(result, opts)
+	}
+
+	return json.NewEncoder(w).Encode(metrics)
+}
+
+// readResultRecords reads results written by -format json.
+func readResultRecords(r io.Reader) ([]ResultRecord, error) {
+	records := make([]ResultRecord, 0)
+	dec := json.NewDecoder(bufio.NewReader(r))
+	for dec.More() {
+		var record ResultRecord
+		if err := dec.Decode(&record); err != nil {
+			return nil, fmt.Errorf("%w: reading results", err)
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// compareResults lists how got differs from want, averages and throughput allowing for the tolerance.
+// Each difference is prefixed by want's title.
+func compareResults(want, got ResultRecord, tolerance float64) []string {
+	diffs := make([]string, 0)
+	differ := func(format string, args ...interface{}) {
+		diffs = append(diffs, want.Title+": "+fmt.Sprintf(format, args...))
+	}
+	for _, m := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"average wait", got.AverageWait, want.AverageWait},
+		{"average turnaround", got.AverageTurnaround, want.AverageTurnaround},
+		{"throughput", got.Throughput, want.Throughput},
+	} {
+		if math.Abs(m.got-m.want) > tolerance {
+			differ("%v is %v, want %v", m.name, m.got, m.want)
+		}
+	}
+
+	for i := 0; i < len(got.Gantt) || i < len(want.Gantt); i++ {
+		switch {
+		case i >= len(want.Gantt):
+			differ("gantt slice %v is %v, want none", i+1, got.Gantt[i])
+		case i >= len(got.Gantt):
+			differ("gantt slice %v is missing, want %v", i+1, want.Gantt[i])
+		case got.Gantt[i] != want.Gantt[i]:
+			differ("gantt slice %v is %v, want %v", i+1, got.Gantt[i], want.Gantt[i])
+		}
+	}
+
+	gotProcesses := make(map[int64]ProcessRecord, len(got.Processes))
+	for _, p := range got.Processes {
+		gotProcesses[p.ID] = p
+	}
+	for _, p := range want.Processes {
+		g, ok := gotProcesses[p.ID]
+		switch {
+		case !ok:
+			differ("process %v is missing", p.ID)
+		case g != p:
+			differ("process %v is %+v, want %+v", p.ID, g, p)
+		}
+	}
+
+	return diffs
+}
+
+// validateResults compares the results with the reference results of the same title, writing every difference
+// to w, and reports whether they all matched.
+func validateResults(w io.Writer, reference []ResultRecord, results []SchedulerResult, opts Options) bool {
+	got := make(map[string]ResultRecord, len(results))
+	for _, result := range results {
+		got[result.Title] = resultRecord(result, opts)
+	}
+
+	matched := true
+	for _, want := range reference {
+		record, ok := got[want.Title]
+		if !ok {
+			_, _ = fmt.Fprintf(w, "%v: not run\n", want.Title)
+			matched = false
+			continue
+		}
+		diffs := compareResults(want, record, opts.Tolerance)
+		for _, diff := range diffs {
+			_, _ = fmt.Fprintln(w, diff)
+		}
+		if len(diffs) > 0 {
+			matched = false
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "%v: matches\n", want.Title)
+	}
+
+	return matched
+}
+
+// loadResultRecords reads the results saved in the file by -format json.
+func loadResultRecords(name string) ([]ResultRecord, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("%v: error opening reference results", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	return readResultRecords(f)
+}
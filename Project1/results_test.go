@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_readResultRecords(t *testing.T) {
+	t.Parallel()
+	opts := DefaultOptions()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+	}
+	results := []SchedulerResult{
+		FCFSSchedule("FCFS", processes, opts),
+		RRSchedule("Round-robin", processes, opts),
+	}
+	var w bytes.Buffer
+	for _, result := range results {
+		outputJSON(&w, result, opts)
+	}
+
+	got, err := readResultRecords(&w)
+	if err != nil {
+		t.Fatalf("readResultRecords() error = %v", err)
+	}
+	want := []ResultRecord{resultRecord(results[0], opts), resultRecord(results[1], opts)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readResultRecords() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_compareResults(t *testing.T) {
+	t.Parallel()
+	reference := ResultRecord{
+		Title:             "FCFS",
+		Gantt:             []SliceRecord{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 8}},
+		Processes:         []ProcessRecord{{ID: 1, Turnaround: 5, Completion: 5}, {ID: 2, Wait: 4, Turnaround: 7, Completion: 8}},
+		AverageWait:       2,
+		AverageTurnaround: 6,
+		Throughput:        0.25,
+	}
+	tests := []struct {
+		name      string
+		change    func(*ResultRecord)
+		wantDiffs []string
+	}{
+		{
+			name:   "identical",
+			change: func(*ResultRecord) {},
+		},
+		{
+			name:   "average within tolerance",
+			change: func(r *ResultRecord) { r.AverageWait = 2.005 },
+		},
+		{
+			name:      "average outside tolerance",
+			change:    func(r *ResultRecord) { r.AverageWait = 2.5 },
+			wantDiffs: []string{"FCFS: average wait is 2.5, want 2"},
+		},
+		{
+			name: "gantt slice",
+			change: func(r *ResultRecord) {
+				r.Gantt = []SliceRecord{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 6, Stop: 9}}
+			},
+			wantDiffs: []string{"FCFS: gantt slice 2 is {2 6 9 0}, want {2 5 8 0}"},
+		},
+		{
+			name:      "missing process",
+			change:    func(r *ResultRecord) { r.Processes = r.Processes[:1] },
+			wantDiffs: []string{"FCFS: process 2 is missing"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := reference
+			got.Gantt = append([]SliceRecord(nil), reference.Gantt...)
+			got.Processes = append([]ProcessRecord(nil), reference.Processes...)
+			tt.change(&got)
+			diffs := compareResults(reference, got, DefaultOptions().Tolerance)
+			if strings.Join(diffs, "\n") != strings.Join(tt.wantDiffs, "\n") {
+				t.Errorf("compareResults() = %q, want %q", diffs, tt.wantDiffs)
+			}
+		})
+	}
+}
+
+func Test_outputMetrics(t *testing.T) {
+	t.Parallel()
+	opts := DefaultOptions()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+	}
+	results := []SchedulerResult{
+		FCFSSchedule("First-come, first-serve", processes, opts),
+		SJFSchedule("Shortest-job-first", processes, opts),
+	}
+	var w bytes.Buffer
+	if err := outputMetrics(&w, results, opts); err != nil {
+		t.Fatalf("outputMetrics() error = %v", err)
+	}
+	if lines := strings.Count(w.String(), "\n"); lines != 1 {
+		t.Errorf("outputMetrics() wrote %v lines, want a single JSON object: %v", lines, w.String())
+	}
+
+	var got map[string]map[string]interface{}
+	if err := json.Unmarshal(w.Bytes(), &got); err != nil {
+		t.Fatalf("outputMetrics() wrote invalid JSON: %v", err)
+	}
+	if len(got) != len(results) {
+		t.Errorf("outputMetrics() has %v schedulers, want %v", len(got), len(results))
+	}
+	for _, result := range results {
+		metrics, ok := got[result.Title]
+		if !ok {
+			t.Errorf("outputMetrics() is missing %q", result.Title)
+			continue
+		}
+		for _, key := range []string{"processes", "average_wait", "average_turnaround", "average_response", "throughput", "makespan"} {
+			if _, ok := metrics[key].(float64); !ok {
+				t.Errorf("outputMetrics() %v %v = %#v, want a number", result.Title, key, metrics[key])
+			}
+		}
+		if len(metrics) != 6 {
+			t.Errorf("outputMetrics() %v has keys %v, want only the aggregate metrics", result.Title, metrics)
+		}
+	}
+	if fcfs := got["First-come, first-serve"]; fcfs["processes"] != 2.0 || fcfs["makespan"] != 8.0 {
+		t.Errorf("outputMetrics() FCFS = %v, want 2 processes and a makespan of 8", fcfs)
+	}
+}
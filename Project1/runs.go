@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"time"
+)
+
+// schedulerRun is a scheduler's result and the output it wrote to its own buffers, so schedulers can run
+// concurrently and still be printed in order.
+type schedulerRun struct {
+	result SchedulerResult
+	// output is what it prints to stdout, events its event log and gantt its gantt CSV,
+	// each empty if that output is off.
+	output, events, gantt bytes.Buffer
+}
+
+// runOutputs are which of a run's outputs to write.
+type runOutputs struct {
+	// stdout is the schedule itself, off when the results are validated against a reference instead.
+	stdout, events, gantt bool
+	// ganttHeader writes the gantt CSV's header, for the first scheduler's run.
+	ganttHeader bool
+	// tui is where the schedule is animated as it's drawn rather than buffered, nil for the plain output.
+	tui io.Writer
+}
+
+// run schedules the processes and writes the outputs of the result to the run's buffers.
+func (s namedScheduler) run(processes []Process, opts Options, outputs runOutputs) *schedulerRun {
+	r := &schedulerRun{result: s.schedule(s.title, processes, opts)}
+	if opts.MaxTime > 0 {
+		r.result = r.result.capped(opts.MaxTime)
+	}
+	if r.result.Err != nil {
+		return r
+	}
+	if outputs.stdout {
+		if outputs.tui != nil {
+			outputTUI(outputs.tui, r.result, opts, time.Sleep)
+		} else {
+			outputResult(&r.output, r.result, opts)
+		}
+		if opts.Explain {
+			outputDecisions(&r.output, explainDecisions(r.result, s.explain, opts), opts)
+		}
+	}
+	// Writing to a buffer can't fail.
+	if outputs.events {
+		_ = writeEventLog(&r.events, r.result, opts.TimeScale)
+	}
+	if outputs.gantt {
+		_ = writeGanttCSV(&r.gantt, r.result, opts, outputs.ganttHeader)
+	}
+
+	return r
+}
+
+// runConcurrently runs each scheduler in its own goroutine, returning the runs in the schedulers' order
+// however long each took.
+func runConcurrently(schedulers []namedScheduler, processes []Process, opts Options, outputs runOutputs) []*schedulerRun {
+	runs := make([]*schedulerRun, len(schedulers))
+	var wg sync.WaitGroup
+	for i := range schedulers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			o := outputs
+			o.ganttHeader = i == 0
+			runs[i] = schedulers[i].run(processes, opts, o)
+		}(i)
+	}
+	wg.Wait()
+
+	return runs
+}
+
+// flush writes the run's buffered outputs, the event log and gantt CSV only if their writers aren't nil.
+func (r *schedulerRun) flush(stdout, events, gantt io.Writer) error {
+	if _, err := r.output.WriteTo(stdout); err != nil {
+		return err
+	}
+	if events != nil {
+		if _, err := r.events.WriteTo(events); err != nil {
+			return err
+		}
+	}
+	if gantt != nil {
+		if _, err := r.gantt.WriteTo(gantt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
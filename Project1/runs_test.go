@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_runConcurrently(t *testing.T) {
+	t.Parallel()
+	// The first scheduler finishes last, but is still printed first.
+	slow := func(delay time.Duration) Scheduler {
+		return func(title string, processes []Process, opts Options) SchedulerResult {
+			time.Sleep(delay)
+			return FCFSSchedule(title, processes, opts)
+		}
+	}
+	schedulers := []namedScheduler{
+		{"Slowest", slow(30 * time.Millisecond), explainArrival},
+		{"Slower", slow(10 * time.Millisecond), explainArrival},
+		{"Fastest", FCFSSchedule, explainArrival},
+	}
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+	}
+	opts := DefaultOptions()
+	opts.PinOutputOrder = true
+
+	var stdout, gantt bytes.Buffer
+	for _, r := range runConcurrently(schedulers, processes, opts, runOutputs{stdout: true, gantt: true}) {
+		if err := r.flush(&stdout, nil, &gantt); err != nil {
+			t.Fatalf("flush() error = %v", err)
+		}
+	}
+	out := stdout.String()
+	if a, b, c := strings.Index(out, "Slowest"), strings.Index(out, "Slower"), strings.Index(out, "Fastest"); a < 0 || a > b || b > c {
+		t.Errorf("runConcurrently() printed the schedulers out of order: %v", out)
+	}
+	if got := strings.Count(gantt.String(), "scheduler,pid,start,stop"); got != 1 {
+		t.Errorf("runConcurrently() wrote the gantt CSV header %v times, want once: %v", got, gantt.String())
+	}
+	if !strings.HasPrefix(gantt.String(), "scheduler,pid,start,stop\nSlowest,") {
+		t.Errorf("runConcurrently() gantt CSV = %q, want the header then the slowest scheduler", gantt.String())
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Rate-monotonic schedulability verdicts.
+const (
+	Schedulable    = "guaranteed schedulable"
+	NotSchedulable = "not schedulable"
+	Inconclusive   = "inconclusive, simulate to be sure"
+)
+
+// Schedulability is the Liu & Layland test of a periodic task set.
+type Schedulability struct {
+	Tasks       int
+	Utilization float64
+	Bound       float64
+	Verdict     string
+}
+
+// liuLaylandBound is the utilization n(2^(1/n) - 1) under which n periodic tasks are always schedulable
+// rate-monotonic.
+func liuLaylandBound(n int) float64 {
+	if n == 0 {
+		return 1
+	}
+
+	return float64(n) * (math.Pow(2, 1/float64(n)) - 1)
+}
+
+// rmSchedulability tests the periodic processes, those with a period, each being a task needing its burst
+// every period. Within the bound the set is schedulable, over a utilization of 1 no scheduler can keep up,
+// and in between only a simulation can tell.
+func rmSchedulability(processes []Process) Schedulability {
+	s := Schedulability{}
+	for _, p := range processes {
+		if p.Period <= 0 || p.Instance > 1 {
+			// A task's later instances are the same task.
+			continue
+		}
+		s.Tasks++
+		s.Utilization += float64(p.BurstDuration) / float64(p.Period)
+	}
+	s.Bound = liuLaylandBound(s.Tasks)
+
+	switch {
+	case s.Utilization <= s.Bound:
+		s.Verdict = Schedulable
+	case s.Utilization > 1:
+		s.Verdict = NotSchedulable
+	default:
+		s.Verdict = Inconclusive
+	}
+
+	return s
+}
+
+func outputSchedulability(w io.Writer, s Schedulability, opts Options) {
+	_, _ = fmt.Fprintf(w, "Rate-monotonic: %v periodic tasks, utilization %v, bound %v: %v\n\n",
+		s.Tasks, formatFloat(s.Utilization, opts.Precision), formatFloat(s.Bound, opts.Precision), s.Verdict)
+}
+
+// ErrDeadlineMissed is returned by assertSchedulable when the earliest-deadline-first schedule misses a deadline.
+var ErrDeadlineMissed = errors.New("deadline missed")
+
+// withImplicitDeadlines gives each periodic process without a deadline the implicit one of its next release,
+// a period after it arrives.
+func withImplicitDeadlines(processes []Process) []Process {
+	implicit := make([]Process, len(processes))
+	copy(implicit, processes)
+	for i := range implicit {
+		if implicit[i].Deadline == 0 && implicit[i].Period > 0 {
+			implicit[i].Deadline = implicit[i].ArrivalTime + implicit[i].Period
+		}
+	}
+
+	return implicit
+}
+
+// assertSchedulable schedules the processes earliest-deadline-first, which on one core meets every deadline
+// if any scheduler can, reporting to w either that every deadline was met or the first to be missed, which is
+// then an ErrDeadlineMissed. Periodic processes without a deadline are due by their next release. It's an error
+// if no process has a deadline.
+func assertSchedulable(w io.Writer, processes []Process, opts Options) error {
+	processes = withImplicitDeadlines(processes)
+	if !hasDeadlines(processes) {
+		return fmt.Errorf("%w: no process has a deadline or period to meet", ErrInvalidArgs)
+	}
+
+	result := EDFSchedule("Earliest-deadline-first", processes, opts)
+	if result.Err != nil {
+		return result.Err
+	}
+	var (
+		first  ProcessStats
+		missed bool
+		due    int
+	)
+	for _, s := range result.Stats {
+		if s.Deadline == 0 {
+			continue
+		}
+		due++
+		if s.Completion > s.Deadline && (!missed || s.Deadline < first.Deadline) {
+			first, missed = s, true
+		}
+	}
+	if missed {
+		_, _ = fmt.Fprintf(w, "Not schedulable: process %v was due at %v but completed at %v\n", first.ProcessID,
+			formatTime(first.Deadline, opts.TimeScale), formatTime(first.Completion, opts.TimeScale))
+		return fmt.Errorf("%w: process %v", ErrDeadlineMissed, first.ProcessID)
+	}
+	_, _ = fmt.Fprintf(w, "Schedulable: all %v deadlines met\n", due)
+
+	return nil
+}
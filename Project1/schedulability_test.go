@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+)
+
+func Test_rmSchedulability(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      string
+	}{
+		{
+			name: "at the bound",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 4, Period: 4},
+			},
+			want: Schedulable,
+		},
+		{
+			name: "below the bound",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 1, Period: 4},
+				{ProcessID: 2, BurstDuration: 2, Period: 8},
+				{ProcessID: 3, BurstDuration: 1, Period: 10},
+			},
+			want: Schedulable,
+		},
+		{
+			name: "above the bound",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 2, Period: 4},
+				{ProcessID: 2, BurstDuration: 3, Period: 8},
+				{ProcessID: 3, BurstDuration: 5},
+			},
+			want: Inconclusive,
+		},
+		{
+			name: "over utilized",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 3, Period: 4},
+				{ProcessID: 2, BurstDuration: 2, Period: 5},
+			},
+			want: NotSchedulable,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := rmSchedulability(tt.processes); got.Verdict != tt.want {
+				t.Errorf("rmSchedulability() = %+v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := liuLaylandBound(2); math.Abs(got-0.8284) > 1e-4 {
+		t.Errorf("liuLaylandBound(2) = %v, want 0.8284", got)
+	}
+}
+
+func Test_assertSchedulable(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      string
+		wantErr   error
+	}{
+		{
+			name: "schedulable",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Deadline: 4},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2, Deadline: 4},
+				{ProcessID: 3, ArrivalTime: 1, BurstDuration: 1, Deadline: 6},
+			},
+			want: "Schedulable: all 3 deadlines met\n",
+		},
+		{
+			// Process 2 is only due by its next release, a period after it arrives, which it can't make.
+			name: "unschedulable",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Deadline: 3},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2, Period: 4},
+				{ProcessID: 3, ArrivalTime: 0, BurstDuration: 1, Deadline: 8},
+			},
+			want:    "Not schedulable: process 2 was due at 4 but completed at 5\n",
+			wantErr: ErrDeadlineMissed,
+		},
+		{
+			name:      "no deadlines",
+			processes: []Process{{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2}},
+			wantErr:   ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			err := assertSchedulable(&w, tt.processes, DefaultOptions())
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("assertSchedulable() error = %v, want %v", err, tt.wantErr)
+			}
+			if w.String() != tt.want {
+				t.Errorf("assertSchedulable() = %q, want %q", w.String(), tt.want)
+			}
+		})
+	}
+}
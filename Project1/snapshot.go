@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// engineScheduler is a scheduler built on preemptiveSchedule, by its quantum and a new pick to carry on running
+// the engine with.
+type engineScheduler struct {
+	// cpuBound schedules the processes without their I/O, as the scheduler does.
+	cpuBound bool
+	// perPriority marks the result for statistics by priority, as the scheduler does.
+	perPriority bool
+	pick        func(opts Options, e *engine) (int64, func(ready []*runnable, time int64) int)
+}
+
+// resumable are the schedulers that can be snapshotted, those whose picks keep no state the engine's doesn't
+// give back, so the engine's state is everything needed to carry on.
+var resumable = map[string]engineScheduler{
+	"fcfs-io": {pick: func(Options, *engine) (int64, func([]*runnable, int64) int) { return 0, pickFirst }},
+	"edf": {pick: func(opts Options, _ *engine) (int64, func([]*runnable, int64) int) {
+		return 0, pickEarliestDeadline(opts)
+	}},
+	"sjf": {cpuBound: true, pick: func(opts Options, e *engine) (int64, func([]*runnable, int64) int) {
+		return 0, pickShortestRemaining(opts, e.lastPicked())
+	}},
+	"priority": {
+		cpuBound:    true,
+		perPriority: true,
+		pick: func(opts Options, _ *engine) (int64, func([]*runnable, int64) int) {
+			return 0, pickShortestThenPriority(opts)
+		},
+	},
+}
+
+// resumableNames lists the schedulers that can be snapshotted, sorted.
+func resumableNames() []string {
+	names := make([]string, 0, len(resumable))
+	for name := range resumable {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Snapshot is a simulation stopped part way through, with everything needed to resume it.
+type Snapshot struct {
+	// Algorithm is the name of the scheduler, one of resumable.
+	Algorithm string `json:"algorithm"`
+	// Processes are the whole workload, for the resumed run's statistics.
+	Processes []Process `json:"processes"`
+	// TimeScale is the ticks per unit of time of every time in the snapshot.
+	TimeScale int64  `json:"time_scale"`
+	State     engine `json:"state"`
+}
+
+// takeSnapshot simulates the processes with the scheduler up to the first event at or after the time.
+func takeSnapshot(algorithm string, processes []Process, at int64, opts Options) (Snapshot, error) {
+	scheduler, ok := resumable[algorithm]
+	if !ok {
+		return Snapshot{}, fmt.Errorf("%w: %q can't be snapshotted, only %v", ErrInvalidArgs,
+			algorithm, strings.Join(resumableNames(), ", "))
+	}
+	running := processes
+	if scheduler.cpuBound {
+		running = cpuBound(processes)
+	}
+	e := newEngine(running, opts)
+	quantum, pick := scheduler.pick(opts, e)
+	e.AbortAtDeadline = opts.AbortAtDeadline && algorithm == "edf"
+	if err := e.run(quantum, pick, at); err != nil {
+		return Snapshot{}, err
+	}
+
+	return Snapshot{Algorithm: algorithm, Processes: processes, TimeScale: opts.ticksPerUnit(), State: *e}, nil
+}
+
+// resume runs a snapshot on to completion, giving the same result as if it had never stopped.
+func (s Snapshot) resume(opts Options) SchedulerResult {
+	scheduler, ok := resumable[s.Algorithm]
+	if !ok {
+		return SchedulerResult{Err: fmt.Errorf("%w: snapshot of unknown scheduler %q", ErrInvalidArgs, s.Algorithm)}
+	}
+	e := s.State
+	e.ctx = opts.ctx
+	quantum, pick := scheduler.pick(opts, &e)
+	err := e.run(quantum, pick, -1)
+
+	processes := s.Processes
+	if scheduler.cpuBound {
+		processes = cpuBound(processes)
+	}
+	result := calculateCompletionStats(algorithms[s.Algorithm].title, processes, e.Gantt)
+	result.PerPriority = scheduler.perPriority
+	if !scheduler.cpuBound {
+		result.IOGantt = e.IOGantt
+	}
+	result.Err = err
+	if len(e.Aborted) > 0 {
+		result = result.withAborted(e.Aborted)
+	}
+
+	return result
+}
+
+func writeSnapshot(w io.Writer, s Snapshot) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(s)
+}
+
+func readSnapshot(r io.Reader) (Snapshot, error) {
+	var s Snapshot
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return Snapshot{}, fmt.Errorf("%w: reading snapshot", err)
+	}
+	if _, ok := resumable[s.Algorithm]; !ok {
+		return Snapshot{}, fmt.Errorf("%w: snapshot of unknown scheduler %q", ErrInvalidArgs, s.Algorithm)
+	}
+	if s.TimeScale < 1 {
+		s.TimeScale = 1
+	}
+
+	return s, nil
+}
+
+// saveSnapshot writes the snapshot to a file as JSON.
+func saveSnapshot(path string, s Snapshot) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("%w: creating snapshot", err)
+	}
+	if err := writeSnapshot(f, s); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("%w: writing snapshot", err)
+	}
+
+	return f.Close()
+}
+
+// loadSnapshot reads a snapshot saved by saveSnapshot.
+func loadSnapshot(path string) (Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("%w: opening snapshot", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	return readSnapshot(f)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSnapshot_resume(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 4, Deadline: 12, IO: []IORequest{{At: 2, Duration: 3}}},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Deadline: 6},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 5, Deadline: 20, IO: []IORequest{{At: 1, Duration: 2}, {At: 3, Duration: 1}}},
+		{ProcessID: 4, ArrivalTime: 9, BurstDuration: 2},
+	}
+	tests := []struct {
+		algorithm string
+		schedule  Scheduler
+	}{
+		{algorithm: "fcfs-io", schedule: FCFSIOSchedule},
+		{algorithm: "edf", schedule: EDFSchedule},
+		{algorithm: "sjf", schedule: SJFSchedule},
+		{algorithm: "priority", schedule: SJFPrioritySchedule},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.algorithm, func(t *testing.T) {
+			t.Parallel()
+			opts := DefaultOptions()
+			want := tt.schedule(algorithms[tt.algorithm].title, processes, opts)
+			// Checkpoint before anything runs, mid-run, during I/O, and after everything has completed.
+			for _, at := range []int64{0, 3, 6, 9, 100} {
+				snapshot, err := takeSnapshot(tt.algorithm, processes, at, opts)
+				if err != nil {
+					t.Fatalf("takeSnapshot() at %v unexpected error: %v", at, err)
+				}
+				var b bytes.Buffer
+				if err := writeSnapshot(&b, snapshot); err != nil {
+					t.Fatalf("writeSnapshot() unexpected error: %v", err)
+				}
+				loaded, err := readSnapshot(&b)
+				if err != nil {
+					t.Fatalf("readSnapshot() unexpected error: %v", err)
+				}
+				got := loaded.resume(opts)
+				if got.Err != nil {
+					t.Fatalf("resume() from %v unexpected error: %v", at, got.Err)
+				}
+				if !reflect.DeepEqual(got.Gantt, want.Gantt) || !reflect.DeepEqual(got.IOGantt, want.IOGantt) ||
+					!reflect.DeepEqual(got.Stats, want.Stats) || got.PerPriority != want.PerPriority {
+					t.Errorf("resume() from %v = %v %v, want %v %v", at, got.Gantt, got.IOGantt, want.Gantt, want.IOGantt)
+				}
+			}
+		})
+	}
+}
+
+func TestSnapshot_resumeSJFTie(t *testing.T) {
+	t.Parallel()
+	// Process 1 arrives with as long left as process 2 has, which SJF keeps running over the lower PID.
+	processes := []Process{
+		{ProcessID: 2, BurstDuration: 4},
+		{ProcessID: 1, ArrivalTime: 2, BurstDuration: 2},
+	}
+	opts := DefaultOptions()
+	opts.TieBreak = TieBreakPID
+	want := SJFSchedule("SJF", processes, opts)
+	snapshot, err := takeSnapshot("sjf", processes, 2, opts)
+	if err != nil {
+		t.Fatalf("takeSnapshot() unexpected error: %v", err)
+	}
+	if got := snapshot.resume(opts); !reflect.DeepEqual(got.Gantt, want.Gantt) {
+		t.Errorf("resume() gantt = %v, want %v", got.Gantt, want.Gantt)
+	}
+}
+
+func TestSnapshot_partial(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+	}
+	snapshot, err := takeSnapshot("fcfs-io", processes, 2, DefaultOptions())
+	if err != nil {
+		t.Fatalf("takeSnapshot() unexpected error: %v", err)
+	}
+	// The snapshot is from the first event at or after time 2, when process 1 finishes.
+	state := snapshot.State
+	if state.Time != 4 || len(state.Pending) != 0 || len(state.Ready) != 1 || state.Ready[0].ProcessID != 2 {
+		t.Errorf("takeSnapshot() state = %+v, want process 2 ready at time 4", state)
+	}
+	if want := []TimeSlice{{PID: 1, Start: 0, Stop: 4}}; !reflect.DeepEqual(state.Gantt, want) {
+		t.Errorf("takeSnapshot() gantt = %v, want %v", state.Gantt, want)
+	}
+}
+
+func Test_takeSnapshot_notResumable(t *testing.T) {
+	t.Parallel()
+	_, err := takeSnapshot("rr", []Process{{ProcessID: 1, BurstDuration: 1}}, 0, DefaultOptions())
+	if !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("takeSnapshot() error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
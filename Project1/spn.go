@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// BurstPrediction is a CPU burst's predicted length against how long it actually ran.
+type BurstPrediction struct {
+	Predicted float64
+	Actual    int64
+}
+
+// burstPredictor predicts each process's next CPU burst by exponential averaging, τ(n+1) = α·t(n) + (1−α)·τ(n),
+// where t(n) is the process's nth burst and τ(0) is the initial prediction.
+type burstPredictor struct {
+	alpha   float64
+	initial float64
+	tau     map[int64]float64
+	// predictions are every burst observed for each process.
+	predictions map[int64][]BurstPrediction
+}
+
+func newBurstPredictor(alpha, initial float64) *burstPredictor {
+	return &burstPredictor{
+		alpha:       alpha,
+		initial:     initial,
+		tau:         make(map[int64]float64),
+		predictions: make(map[int64][]BurstPrediction),
+	}
+}
+
+// predict is the process's predicted next burst.
+func (b *burstPredictor) predict(pid int64) float64 {
+	if tau, ok := b.tau[pid]; ok {
+		return tau
+	}
+
+	return b.initial
+}
+
+// observe records the process's latest burst against its prediction, updating the prediction of the next.
+func (b *burstPredictor) observe(pid, burst int64) {
+	tau := b.predict(pid)
+	b.predictions[pid] = append(b.predictions[pid], BurstPrediction{Predicted: tau, Actual: burst})
+	b.tau[pid] = b.alpha*float64(burst) + (1-b.alpha)*tau
+}
+
+// SPNSchedule (shortest process next) runs the ready process with the shortest predicted next CPU burst,
+// without preemption, as a real scheduler can't know the actual burst. Bursts are the CPU time between a
+// process's I/O requests, each predicted by exponential averaging of the process's earlier bursts with
+// opts.Alpha, starting from opts.InitialPrediction. Ties go by opts.TieBreak.
+func SPNSchedule(title string, inputProcesses []Process, opts Options) SchedulerResult {
+	predictor := newBurstPredictor(opts.Alpha, float64(opts.InitialPrediction))
+	var (
+		// current is the process whose burst is running, started before its I/O request currentIO.
+		current   *runnable
+		currentIO int
+	)
+	pick := func(ready []*runnable, _ int64) int {
+		for i := range ready {
+			if ready[i] == current && ready[i].NextIO == currentIO {
+				return i
+			}
+		}
+
+		best := 0
+		for i := 1; i < len(ready); i++ {
+			a, b := predictor.predict(ready[i].ProcessID), predictor.predict(ready[best].ProcessID)
+			if a < b || (a == b && opts.before(ready[i].Process, ready[best].Process, false)) {
+				best = i
+			}
+		}
+		current, currentIO = ready[best], ready[best].NextIO
+		// The burst runs to its end, so it can be observed as soon as it's dispatched.
+		burst := current.untilIO()
+		if burst < 0 {
+			burst = current.Remaining
+		}
+		predictor.observe(current.ProcessID, burst)
+
+		return best
+	}
+	gantt, ioGantt, err := preemptiveSchedule(inputProcesses, 0, opts, pick)
+
+	result := calculateCompletionStats(title, inputProcesses, gantt)
+	result.IOGantt = ioGantt
+	result.Predictions = predictor.predictions
+	result.Err = err
+
+	return result
+}
+
+// explainPrediction explains SPNSchedule's choices, whose predictions are listed after its schedule.
+func explainPrediction(candidate, []candidate, Options) string {
+	return "shortest predicted next burst"
+}
+
+// predictionError is the mean absolute difference between the bursts' predicted and actual lengths.
+func predictionError(predictions []BurstPrediction) float64 {
+	if len(predictions) == 0 {
+		return 0
+	}
+	var total float64
+	for _, p := range predictions {
+		total += math.Abs(p.Predicted - float64(p.Actual))
+	}
+
+	return total / float64(len(predictions))
+}
+
+// outputPredictions lists each process's predicted and actual bursts, with the mean error of the predictions.
+func outputPredictions(w io.Writer, predictions map[int64][]BurstPrediction, opts Options) {
+	pids := make([]int64, 0, len(predictions))
+	for pid := range predictions {
+		pids = append(pids, pid)
+	}
+	sort.Slice(pids, func(a, b int) bool { return pids[a] < pids[b] })
+
+	_, _ = fmt.Fprintln(w, "Burst predictions")
+	scale := float64(opts.ticksPerUnit())
+	table := newTable(w, opts)
+	table.SetHeader([]string{"ID", "Predicted", "Actual", "Mean error"})
+	for _, pid := range pids {
+		predicted, actual := "", ""
+		for i, p := range predictions[pid] {
+			if i > 0 {
+				predicted, actual = predicted+" ", actual+" "
+			}
+			predicted += formatFloat(p.Predicted/scale, opts.Precision)
+			actual += formatTime(p.Actual, opts.TimeScale)
+		}
+		table.Append([]string{
+			fmt.Sprint(pid),
+			predicted,
+			actual,
+			formatFloat(predictionError(predictions[pid])/scale, opts.Precision),
+		})
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_burstPredictor(t *testing.T) {
+	t.Parallel()
+	// The textbook sequence with α = 1/2 and τ(0) = 10.
+	bursts := []int64{6, 4, 6, 4, 13, 13, 13}
+	predictor := newBurstPredictor(0.5, 10)
+	for _, burst := range bursts {
+		predictor.observe(1, burst)
+	}
+
+	want := []float64{10, 8, 6, 6, 5, 9, 11}
+	got := make([]float64, 0, len(want))
+	for _, p := range predictor.predictions[1] {
+		got = append(got, p.Predicted)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("burstPredictor predictions = %v, want %v", got, want)
+	}
+	if next := predictor.predict(1); next != 12 {
+		t.Errorf("burstPredictor.predict() = %v, want 12", next)
+	}
+	if got, want := predictionError(predictor.predictions[1]), 24.0/7; got != want {
+		t.Errorf("predictionError() = %v, want %v", got, want)
+	}
+}
+
+func TestSPNSchedule(t *testing.T) {
+	t.Parallel()
+	// Every first burst is predicted the same, but the short ones bring their processes' next predictions down,
+	// so process 2 returning from I/O is predicted to beat process 3, which hasn't run yet.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, IO: []IORequest{{At: 1, Duration: 1}}},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4, IO: []IORequest{{At: 3, Duration: 1}}},
+		{ProcessID: 3, ArrivalTime: 0, BurstDuration: 6},
+	}
+	opts := DefaultOptions()
+	opts.Alpha, opts.InitialPrediction = 0.5, 5
+	got := SPNSchedule("SPN", processes, opts)
+
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 1},
+		{PID: 2, Start: 1, Stop: 4},
+		{PID: 1, Start: 4, Stop: 8},
+		{PID: 2, Start: 8, Stop: 9},
+		{PID: 3, Start: 9, Stop: 15},
+	}
+	if !reflect.DeepEqual(got.Gantt, want) {
+		t.Errorf("SPNSchedule() gantt = %v, want %v", got.Gantt, want)
+	}
+	wantPredictions := map[int64][]BurstPrediction{
+		1: {{Predicted: 5, Actual: 1}, {Predicted: 3, Actual: 4}},
+		2: {{Predicted: 5, Actual: 3}, {Predicted: 4, Actual: 1}},
+		3: {{Predicted: 5, Actual: 6}},
+	}
+	if !reflect.DeepEqual(got.Predictions, wantPredictions) {
+		t.Errorf("SPNSchedule() predictions = %v, want %v", got.Predictions, wantPredictions)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// starvedProcesses returns the stats of processes that waited longer than opts.StarvationWait, or longer than
+// opts.StarvationFactor times their burst. A zero threshold turns that check off.
+func starvedProcesses(stats []ProcessStats, opts Options) []ProcessStats {
+	starved := make([]ProcessStats, 0)
+	for _, s := range stats {
+		switch {
+		case opts.StarvationWait > 0 && s.Wait > opts.StarvationWait:
+		case opts.StarvationFactor > 0 && float64(s.Wait) > opts.StarvationFactor*float64(s.BurstDuration):
+		default:
+			continue
+		}
+		starved = append(starved, s)
+	}
+
+	return starved
+}
+
+func outputStarvation(w io.Writer, starved []ProcessStats, opts Options) {
+	for _, s := range starved {
+		if opts.StarvationWait > 0 && s.Wait > opts.StarvationWait {
+			_, _ = fmt.Fprintf(w, "Warning: process %v starved, waited %v which is over %v\n",
+				s.ProcessID, formatTime(s.Wait, opts.TimeScale), formatTime(opts.StarvationWait, opts.TimeScale))
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "Warning: process %v starved, waited %v which is over %vx its burst of %v\n",
+			s.ProcessID, formatTime(s.Wait, opts.TimeScale), opts.StarvationFactor, formatTime(s.BurstDuration, opts.TimeScale))
+	}
+	if len(starved) > 0 {
+		_, _ = fmt.Fprintln(w)
+	}
+}
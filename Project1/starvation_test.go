@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_starvedProcesses(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		schedule  Scheduler
+		processes []Process
+		opts      func(*Options)
+		wantPIDs  []int64
+		wantOut   string
+	}{
+		{
+			name:     "SJF starves the long job behind short ones",
+			schedule: SJFSchedule,
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 10},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: 3, ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: 4, ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: 5, ArrivalTime: 0, BurstDuration: 2},
+			},
+			opts:     func(o *Options) { o.StarvationWait = 6 },
+			wantPIDs: []int64{1},
+			wantOut:  "Warning: process 1 starved, waited 8 which is over 6\n",
+		},
+		{
+			name:     "FCFS convoy starves the short job relative to its burst",
+			schedule: FCFSSchedule,
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 10},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1},
+			},
+			opts:     func(o *Options) { o.StarvationFactor = 5 },
+			wantPIDs: []int64{2},
+			wantOut:  "Warning: process 2 starved, waited 9 which is over 5x its burst of 1\n",
+		},
+		{
+			name:     "off by default",
+			schedule: SJFSchedule,
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 10},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+			},
+			opts: func(*Options) {},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opts := DefaultOptions()
+			tt.opts(&opts)
+			result := tt.schedule("", tt.processes, opts)
+
+			starved := starvedProcesses(result.Stats, opts)
+			if len(starved) != len(tt.wantPIDs) {
+				t.Fatalf("starvedProcesses() = %v, want PIDs %v", starved, tt.wantPIDs)
+			}
+			for i := range starved {
+				if starved[i].ProcessID != tt.wantPIDs[i] {
+					t.Errorf("starvedProcesses() PID = %v, want %v", starved[i].ProcessID, tt.wantPIDs[i])
+				}
+			}
+
+			var w bytes.Buffer
+			outputResult(&w, result, opts)
+			if tt.wantOut != "" && !strings.Contains(w.String(), tt.wantOut) {
+				t.Errorf("outputResult() missing %q: %v", tt.wantOut, w.String())
+			}
+			if tt.wantOut == "" && strings.Contains(w.String(), "Warning") {
+				t.Errorf("outputResult() unexpected warning: %v", w.String())
+			}
+		})
+	}
+}
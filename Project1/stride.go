@@ -0,0 +1,52 @@
+package main
+
+// strideScale is divided by a process's tickets to get its stride. It's divisible by every ticket count
+// from 1 to 16 so common ticket ratios stay exact.
+const strideScale int64 = 720720
+
+// strideQuantum is how long, in units of time, a process runs each time it's picked by the stride scheduler.
+const strideQuantum int64 = 1
+
+// StrideSchedule schedules processes deterministically in proportion to their tickets, read from Weight
+// where a bigger number is more tickets. Every quantum the ready process with the lowest pass runs and its pass
+// advances by its stride, strideScale divided by its tickets. Arriving processes start at the lowest pass already
+// ready so they can't monopolize the CPU catching up. Ties go by opts.TieBreak.
+func StrideSchedule(title string, inputProcesses []Process, opts Options) SchedulerResult {
+	pass := make(map[int64]int64, len(inputProcesses))
+	stride := func(p Process) int64 {
+		return strideScale / p.weight()
+	}
+
+	gantt, ioGantt, err := preemptiveSchedule(inputProcesses, strideQuantum*opts.ticksPerUnit(), opts, func(ready []*runnable, _ int64) int {
+		var lowest int64 = -1
+		for i := range ready {
+			if p, ok := pass[ready[i].ProcessID]; ok && (lowest < 0 || p < lowest) {
+				lowest = p
+			}
+		}
+		if lowest < 0 {
+			lowest = 0
+		}
+
+		best := -1
+		for i := range ready {
+			p, ok := pass[ready[i].ProcessID]
+			if !ok {
+				p = lowest
+				pass[ready[i].ProcessID] = p
+			}
+			if best < 0 || p < pass[ready[best].ProcessID] ||
+				(p == pass[ready[best].ProcessID] && opts.before(ready[i].Process, ready[best].Process, false)) {
+				best = i
+			}
+		}
+		pass[ready[best].ProcessID] += stride(ready[best].Process)
+
+		return best
+	})
+	result := calculateCompletionStats(title, inputProcesses, gantt)
+	result.IOGantt = ioGantt
+	result.Err = err
+
+	return result
+}
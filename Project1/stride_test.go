@@ -0,0 +1,70 @@
+package main
+
+import "testing"
+
+// cpuTime sums how long each PID ran in the gantt before a time.
+func cpuTime(gantt []TimeSlice, before int64) map[int64]int64 {
+	ran := make(map[int64]int64)
+	for _, s := range gantt {
+		if s.Start >= before {
+			continue
+		}
+		stop := s.Stop
+		if stop > before {
+			stop = before
+		}
+		ran[s.PID] += stop - s.Start
+	}
+
+	return ran
+}
+
+func TestStrideSchedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		window    int64
+		want      map[int64]int64
+	}{
+		{
+			name: "3:1 tickets",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 40, Weight: 3},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 40, Weight: 1},
+			},
+			window: 8,
+			want:   map[int64]int64{1: 6, 2: 2},
+		},
+		{
+			name: "equal tickets alternate",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 40, Weight: 2},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 40, Weight: 2},
+			},
+			window: 10,
+			want:   map[int64]int64{1: 5, 2: 5},
+		},
+		{
+			name: "late arrival starts level instead of catching up",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 40, Weight: 1},
+				{ProcessID: 2, ArrivalTime: 10, BurstDuration: 40, Weight: 1},
+			},
+			window: 20,
+			want:   map[int64]int64{1: 15, 2: 5},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := cpuTime(StrideSchedule("Stride", tt.processes, DefaultOptions()).Gantt, tt.window)
+			for pid, want := range tt.want {
+				if got[pid] != want {
+					t.Errorf("StrideSchedule() process %v ran %v of the first %v, want %v", pid, got[pid], tt.window, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// sweepProcesses is how many processes each load of a sweep schedules, enough for the queue to settle.
+const sweepProcesses = 200
+
+// meanGeneratedBurst is the mean burst of generated processes, uniform from 1 to generatedMaxBurst.
+const meanGeneratedBurst = (1 + generatedMaxBurst) / 2.0
+
+// generatePoissonWorkload makes n CPU bound processes arriving as a Poisson process that offers the load,
+// the fraction of the CPU their bursts would keep busy: the times between arrivals are exponential with a mean
+// of the mean burst over the load. The draws don't depend on the load, so the same seed gives the same
+// workload at every load, only spread out or squeezed together.
+func generatePoissonWorkload(rng *rand.Rand, n int, load float64) []Process {
+	processes := make([]Process, n)
+	var arrival float64
+	for i := range processes {
+		gap := rng.ExpFloat64() * meanGeneratedBurst / load
+		processes[i] = Process{
+			ProcessID:     int64(i + 1),
+			ArrivalTime:   int64(math.Ceil(arrival)),
+			BurstDuration: 1 + rng.Int63n(generatedMaxBurst),
+			Priority:      1 + rng.Int63n(generatedPriorities),
+		}
+		arrival += gap
+	}
+
+	return processes
+}
+
+// LoadSweep is the offered loads -load-sweep runs the schedulers at, From to To every Step.
+type LoadSweep struct {
+	From, To, Step float64
+}
+
+// parseLoadSweep parses a "from:to:step" load range.
+func parseLoadSweep(s string) (LoadSweep, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return LoadSweep{}, fmt.Errorf("%w: load sweep %q isn't from:to:step", ErrInvalidArgs, s)
+	}
+	bounds := make([]float64, len(parts))
+	for i := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(parts[i]), 64)
+		if err != nil || f <= 0 {
+			return LoadSweep{}, fmt.Errorf("%w: load sweep %q needs positive numbers", ErrInvalidArgs, s)
+		}
+		bounds[i] = f
+	}
+	if bounds[1] < bounds[0] {
+		return LoadSweep{}, fmt.Errorf("%w: load sweep %q ends before it starts", ErrInvalidArgs, s)
+	}
+
+	return LoadSweep{From: bounds[0], To: bounds[1], Step: bounds[2]}, nil
+}
+
+// loads are the offered loads of the sweep in order.
+func (s LoadSweep) loads() []float64 {
+	loads := make([]float64, 0)
+	// Counting steps rather than adding them up keeps rounding from skipping the last load.
+	for i := 0; s.From+float64(i)*s.Step <= s.To+s.Step/2; i++ {
+		loads = append(loads, s.From+float64(i)*s.Step)
+	}
+
+	return loads
+}
+
+// SweepPoint is one scheduler's averages at one offered load.
+type SweepPoint struct {
+	Load       float64
+	Title      string
+	Wait       float64
+	Throughput float64
+}
+
+// loadSweep runs the selected schedulers on a Poisson workload seeded by opts.Seed at each load of
+// opts.LoadSweep, in load order and then the schedulers' order.
+func loadSweep(opts Options) []SweepPoint {
+	points := make([]SweepPoint, 0)
+	for _, load := range opts.LoadSweep.loads() {
+		processes := generatePoissonWorkload(newSeededRand(opts.Seed), sweepProcesses, load)
+		for _, s := range selectSchedulers(opts, processes) {
+			result := s.schedule(s.title, processes, opts)
+			points = append(points, SweepPoint{Load: load, Title: s.title, Wait: result.AveWait, Throughput: result.Throughput})
+		}
+	}
+
+	return points
+}
+
+func outputLoadSweep(w io.Writer, points []SweepPoint, opts Options) {
+	_, _ = fmt.Fprintf(w, "Average wait and throughput by offered load, %v Poisson arrivals (seed %v)\n", sweepProcesses, opts.Seed)
+	table := newTable(w, opts)
+	table.SetHeader([]string{"Load", "Scheduler", "Wait", "Throughput"})
+	for _, p := range points {
+		table.Append([]string{
+			formatFloat(p.Load, opts.Precision),
+			p.Title,
+			formatFloat(p.Wait, opts.Precision),
+			formatFloat(p.Throughput, opts.Precision),
+		})
+	}
+	table.Render()
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_loadSweep(t *testing.T) {
+	t.Parallel()
+	opts := DefaultOptions()
+	opts.Algorithms = []string{"fcfs"}
+	opts.LoadSweep = LoadSweep{From: 0.2, To: 1.2, Step: 0.2}
+	points := loadSweep(opts)
+	if len(points) != 6 {
+		t.Fatalf("loadSweep() = %v points, want 6", len(points))
+	}
+	for i := 1; i < len(points); i++ {
+		if points[i].Wait <= points[i-1].Wait {
+			t.Errorf("loadSweep() wait %v at load %v isn't more than %v at load %v",
+				points[i].Wait, points[i].Load, points[i-1].Wait, points[i-1].Load)
+		}
+	}
+}
+
+func Test_parseLoadSweep(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		sweep   string
+		want    LoadSweep
+		wantErr bool
+	}{
+		{sweep: "0.1:0.9:0.2", want: LoadSweep{From: 0.1, To: 0.9, Step: 0.2}},
+		{sweep: "0.1:0.9", wantErr: true},
+		{sweep: "0.9:0.1:0.2", wantErr: true},
+		{sweep: "0.1:0.9:0", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.sweep, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseLoadSweep(tt.sweep)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseLoadSweep() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseLoadSweep() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadSweep_loads(t *testing.T) {
+	t.Parallel()
+	// 0.1 + 3×0.3 falls just short of 1 in floating point, which mustn't drop the last load.
+	got := (LoadSweep{From: 0.1, To: 1, Step: 0.3}).loads()
+	if len(got) != 4 || math.Abs(got[3]-1) > 1e-9 {
+		t.Errorf("loads() = %v, want 0.1, 0.4, 0.7 and 1", got)
+	}
+}
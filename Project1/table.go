@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// table is the part of tablewriter.Table the outputs use, so -plain can render them as a plainTable instead.
+type table interface {
+	SetHeader(keys []string)
+	Append(row []string)
+	AppendBulk(rows [][]string)
+	SetFooter(keys []string)
+	Render()
+}
+
+// Table themes, the styles of -theme for the boxed tables.
+const (
+	// ThemeDefault boxes every cell of the header and footer, but not between rows.
+	ThemeDefault = "default"
+	// ThemeMarkdown draws the table as a Markdown table.
+	ThemeMarkdown = "markdown"
+	// ThemeBordered boxes every row too.
+	ThemeBordered = "bordered"
+	// ThemeCompact drops the borders, leaving the columns aligned by spaces.
+	ThemeCompact = "compact"
+)
+
+// themes are the valid -theme values.
+var themes = []string{ThemeDefault, ThemeMarkdown, ThemeBordered, ThemeCompact}
+
+// newTable is a boxed table writing to w in the opts.Theme, or a plainTable with opts.Plain.
+func newTable(w io.Writer, opts Options) table {
+	if opts.Plain {
+		return &plainTable{w: w}
+	}
+
+	t := tablewriter.NewWriter(w)
+	switch opts.Theme {
+	case ThemeMarkdown:
+		t.SetBorders(tablewriter.Border{Left: true, Right: true})
+		t.SetCenterSeparator("|")
+	case ThemeBordered:
+		t.SetRowLine(true)
+	case ThemeCompact:
+		t.SetBorder(false)
+		t.SetHeaderLine(false)
+		t.SetColumnSeparator("")
+		t.SetCenterSeparator("")
+		t.SetRowSeparator("")
+		t.SetNoWhiteSpace(true)
+		t.SetTablePadding("  ")
+	}
+
+	return t
+}
+
+// plainTable renders a table as tab separated columns, a header line then a line per row and the footer,
+// so it can be grepped, diffed and cut without the boxes. Line breaks within a cell become spaces.
+type plainTable struct {
+	w      io.Writer
+	header []string
+	rows   [][]string
+	footer []string
+}
+
+func (t *plainTable) SetHeader(keys []string)    { t.header = keys }
+func (t *plainTable) Append(row []string)        { t.rows = append(t.rows, row) }
+func (t *plainTable) AppendBulk(rows [][]string) { t.rows = append(t.rows, rows...) }
+func (t *plainTable) SetFooter(keys []string)    { t.footer = keys }
+
+func (t *plainTable) Render() {
+	line := func(cells []string) {
+		fields := make([]string, len(cells))
+		for i, c := range cells {
+			fields[i] = strings.ReplaceAll(c, "\n", " ")
+		}
+		_, _ = fmt.Fprintln(t.w, strings.Join(fields, "\t"))
+	}
+	if t.header != nil {
+		line(t.header)
+	}
+	for _, row := range t.rows {
+		line(row)
+	}
+	if t.footer != nil {
+		line(t.footer)
+	}
+}
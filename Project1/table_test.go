@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_outputSchedule_plain(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 1},
+	}
+	opts := DefaultOptions()
+	opts.Plain = true
+	result := FCFSSchedule("FCFS", processes, opts)
+	var w bytes.Buffer
+	wait, turnaround, throughput := result.averages(opts.TimeScale)
+	outputSchedule(&w, result.rows(opts.TimeScale), wait, turnaround, throughput, opts)
+
+	want := []string{
+		"Schedule table",
+		"ID\tPriority\tBurst\tArrival\tWait\tTurnaround\tExit",
+		"1\t2\t5\t0\t0\t5\t5",
+		"2\t1\t3\t1\t4\t7\t8",
+		"\t\t\t\tAverage 2.00\tAverage 6.00\tThroughput 0.25/t",
+	}
+	if got := strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n"); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("outputSchedule() plain = %q, want %q", got, want)
+	}
+}
+
+func Test_outputSchedule_theme(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 1},
+	}
+	tests := []struct {
+		theme       string
+		wantBorders bool
+		want        string
+	}{
+		{theme: ThemeDefault, wantBorders: true, want: "+----+"},
+		{theme: ThemeMarkdown, wantBorders: true, want: "|----|"},
+		{theme: ThemeBordered, wantBorders: true, want: "+----+"},
+		{theme: ThemeCompact, wantBorders: false, want: "ID  PRIORITY"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.theme, func(t *testing.T) {
+			t.Parallel()
+			opts := DefaultOptions()
+			opts.Theme = tt.theme
+			result := FCFSSchedule("FCFS", processes, opts)
+			var w bytes.Buffer
+			wait, turnaround, throughput := result.averages(opts.TimeScale)
+			outputSchedule(&w, result.rows(opts.TimeScale), wait, turnaround, throughput, opts)
+
+			if got := strings.ContainsAny(w.String(), "+|"); got != tt.wantBorders {
+				t.Errorf("outputSchedule() with %v has borders %v, want %v:\n%v", tt.theme, got, tt.wantBorders, w.String())
+			}
+			if !strings.Contains(w.String(), tt.want) {
+				t.Errorf("outputSchedule() with %v = \n%v, want %q", tt.theme, w.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// throughputWindows buckets the completions into consecutive windows of the given length,
+// a completion at a window's end counting toward that window.
+func throughputWindows(stats []ProcessStats, window int64) []int {
+	buckets := make([]int, 0)
+	for _, s := range stats {
+		i := 0
+		if s.Completion > 0 {
+			i = int((s.Completion - 1) / window)
+		}
+		for len(buckets) <= i {
+			buckets = append(buckets, 0)
+		}
+		buckets[i]++
+	}
+
+	return buckets
+}
+
+func outputThroughputWindows(w io.Writer, buckets []int, opts Options) {
+	_, _ = fmt.Fprintf(w, "Completions per %v\n", formatTime(opts.ThroughputWindow, opts.TimeScale))
+	labels := make([]string, len(buckets))
+	width := 0
+	for i := range buckets {
+		labels[i] = fmt.Sprintf("(%v, %v]",
+			formatTime(int64(i)*opts.ThroughputWindow, opts.TimeScale),
+			formatTime(int64(i+1)*opts.ThroughputWindow, opts.TimeScale))
+		if len(labels[i]) > width {
+			width = len(labels[i])
+		}
+	}
+	for i, count := range buckets {
+		line := fmt.Sprintf("%-*v %3v %v", width, labels[i], count, repeat("#", count))
+		_, _ = fmt.Fprintln(w, strings.TrimRight(line, " "))
+	}
+	_, _ = fmt.Fprintln(w)
+}
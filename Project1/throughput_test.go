@@ -0,0 +1,32 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_throughputWindows(t *testing.T) {
+	t.Parallel()
+	stats := make([]ProcessStats, 0)
+	for _, completion := range []int64{3, 7, 10, 11, 35, 38} {
+		stats = append(stats, ProcessStats{Completion: completion})
+	}
+	tests := []struct {
+		name   string
+		window int64
+		want   []int
+	}{
+		{name: "tens", window: 10, want: []int{3, 1, 0, 2}},
+		{name: "twenties", window: 20, want: []int{4, 2}},
+		{name: "one window", window: 40, want: []int{6}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := throughputWindows(stats, tt.window); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("throughputWindows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Timeline states of a process between arriving and completing.
+const (
+	TimelineWaiting = "wait"
+	TimelineRunning = "run"
+	TimelineIO      = "I/O"
+)
+
+// TimelineInterval is a stretch of a process's time in the system spent in one state.
+type TimelineInterval struct {
+	Start int64
+	Stop  int64
+	State string
+}
+
+// waitTimeline splits the process's time in the system, from its arrival to its completion, into the intervals
+// it was running, using the I/O device, and waiting for either while the slices of other processes ran. Time
+// queued for the I/O device counts as waiting, as it does in the process's wait. It's nil if the result didn't
+// complete the process.
+func waitTimeline(result SchedulerResult, pid int64) []TimelineInterval {
+	var stats *ProcessStats
+	for i := range result.Stats {
+		if result.Stats[i].ProcessID == pid {
+			stats = &result.Stats[i]
+		}
+	}
+	if stats == nil {
+		return nil
+	}
+	busy := make([]TimelineInterval, 0)
+	for _, s := range result.Gantt {
+		if s.PID == pid {
+			busy = append(busy, TimelineInterval{Start: s.Start, Stop: s.Stop, State: TimelineRunning})
+		}
+	}
+	for _, s := range result.IOGantt {
+		if s.PID == pid {
+			busy = append(busy, TimelineInterval{Start: s.Start, Stop: s.Stop, State: TimelineIO})
+		}
+	}
+	sort.SliceStable(busy, func(a, b int) bool { return busy[a].Start < busy[b].Start })
+
+	timeline := make([]TimelineInterval, 0, 2*len(busy))
+	at := stats.ArrivalTime
+	for _, b := range busy {
+		if b.Start > at {
+			timeline = append(timeline, TimelineInterval{Start: at, Stop: b.Start, State: TimelineWaiting})
+		}
+		timeline = append(timeline, b)
+		at = b.Stop
+	}
+
+	return timeline
+}
+
+// waitingIntervals are the intervals of the timeline spent waiting.
+func waitingIntervals(timeline []TimelineInterval) []TimelineInterval {
+	waiting := make([]TimelineInterval, 0)
+	for _, t := range timeline {
+		if t.State == TimelineWaiting {
+			waiting = append(waiting, t)
+		}
+	}
+
+	return waiting
+}
+
+// outputWaitTimeline draws the process's timeline as a gantt of its own, then totals its waiting.
+func outputWaitTimeline(w io.Writer, pid int64, timeline []TimelineInterval, opts Options) {
+	if timeline == nil {
+		_, _ = fmt.Fprintf(w, "Process %v didn't complete, so has no timeline\n\n", pid)
+		return
+	}
+	_, _ = fmt.Fprintf(w, "Process %v timeline\n", pid)
+	cells := make([]string, len(timeline))
+	starts := make([]int64, len(timeline), len(timeline)+1)
+	for i, t := range timeline {
+		cells[i] = ganttCell(t.State, " ", opts.GanttJustify) + "|"
+		starts[i] = t.Start
+	}
+	if len(timeline) > 0 {
+		starts = append(starts, timeline[len(timeline)-1].Stop)
+	}
+	for _, band := range ganttBands(cells, opts.Width) {
+		_, _ = fmt.Fprintln(w, "|"+strings.Join(cells[band[0]:band[1]], ""))
+		for _, start := range starts[band[0]:band[1]] {
+			_, _ = fmt.Fprint(w, formatTime(start, opts.TimeScale), "\t")
+		}
+		if band[1] < len(starts) {
+			_, _ = fmt.Fprint(w, formatTime(starts[band[1]], opts.TimeScale))
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+	var total int64
+	waiting := waitingIntervals(timeline)
+	for _, t := range waiting {
+		total += t.Stop - t.Start
+	}
+	_, _ = fmt.Fprintf(w, "Waited %v over %v intervals\n\n", formatTime(total, opts.TimeScale), len(waiting))
+}
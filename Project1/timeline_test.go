@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_waitTimeline(t *testing.T) {
+	t.Parallel()
+	// Process 2 waits for process 1, runs, is preempted by the shorter process 3, and waits again.
+	result := SJFSchedule("Shortest-job-first", []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 4},
+		{ProcessID: 3, ArrivalTime: 3, BurstDuration: 1},
+	}, DefaultOptions())
+
+	timeline := waitTimeline(result, 2)
+	want := []TimelineInterval{
+		{Start: 1, Stop: 2, State: TimelineWaiting},
+		{Start: 2, Stop: 3, State: TimelineRunning},
+		{Start: 3, Stop: 4, State: TimelineWaiting},
+		{Start: 4, Stop: 7, State: TimelineRunning},
+	}
+	if !reflect.DeepEqual(timeline, want) {
+		t.Fatalf("waitTimeline() = %v, want %v", timeline, want)
+	}
+	if got := waitingIntervals(timeline); !reflect.DeepEqual(got, []TimelineInterval{want[0], want[2]}) {
+		t.Errorf("waitingIntervals() = %v, want %v", got, []TimelineInterval{want[0], want[2]})
+	}
+	if got := waitTimeline(result, 9); got != nil {
+		t.Errorf("waitTimeline() = %v for an unknown process, want nil", got)
+	}
+
+	var w bytes.Buffer
+	outputWaitTimeline(&w, 2, timeline, DefaultOptions())
+	for _, line := range []string{"|  wait  |  run  |  wait  |  run  |", "1\t2\t3\t4\t7", "Waited 2 over 2 intervals"} {
+		if !strings.Contains(w.String(), line) {
+			t.Errorf("outputWaitTimeline() missing %q, got:\n%v", line, w.String())
+		}
+	}
+}
+
+func Test_waitTimeline_io(t *testing.T) {
+	t.Parallel()
+	result := FCFSIOSchedule("First-come, first-serve (I/O)", []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, IO: []IORequest{{At: 1, Duration: 2}}},
+	}, DefaultOptions())
+	want := []TimelineInterval{
+		{Start: 0, Stop: 1, State: TimelineRunning},
+		{Start: 1, Stop: 3, State: TimelineIO},
+		{Start: 3, Stop: 5, State: TimelineRunning},
+	}
+	if got := waitTimeline(result, 1); !reflect.DeepEqual(got, want) {
+		t.Errorf("waitTimeline() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxDecimals is the most decimal places a time in the input may have, keeping the scaled ticks well inside an int64.
+const maxDecimals = 6
+
+// Times are scheduled as whole ticks so fractional workloads stay exact, with no floating point drift.
+// A workload whose times have at most d decimal places is read with 10^d ticks per unit of time,
+// so integer workloads keep one tick per unit and are scheduled exactly as before.
+
+// decimals counts the digits after the decimal point of a number.
+func decimals(s string) int {
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(strings.TrimSpace(s[i+1:]))
+	}
+
+	return 0
+}
+
+// timeScale is the ticks per unit of time needed to represent every time in the fields exactly.
+func timeScale(fields ...string) (int64, error) {
+	most := 0
+	for _, f := range fields {
+		if d := decimals(f); d > most {
+			most = d
+		}
+	}
+	if most > maxDecimals {
+		return 0, fmt.Errorf("%w: times can have at most %v decimal places", ErrInvalidArgs, maxDecimals)
+	}
+
+	var scale int64 = 1
+	for i := 0; i < most; i++ {
+		scale *= 10
+	}
+
+	return scale, nil
+}
+
+// scalePlaces is the decimal places a time scale, a power of ten, can represent.
+func scalePlaces(scale int64) int {
+	places := 0
+	for ; scale > 1; scale /= 10 {
+		places++
+	}
+
+	return places
+}
+
+// parseTicks parses a decimal time such as "1.25" into ticks at the scale, 125 at a scale of 100.
+func parseTicks(s string, scale int64) (int64, error) {
+	places := scalePlaces(scale)
+	whole, frac, _ := strings.Cut(strings.TrimSpace(s), ".")
+	if len(frac) > places {
+		return 0, fmt.Errorf("%w: %q has more decimal places than the time scale", ErrInvalidArgs, s)
+	}
+
+	return strconv.ParseInt(whole+frac+strings.Repeat("0", places-len(frac)), 10, 64)
+}
+
+// formatTime formats ticks as units of time, fractional when the scale is more than one tick per unit.
+func formatTime(ticks, scale int64) string {
+	if scale <= 1 {
+		return strconv.FormatInt(ticks, 10)
+	}
+
+	return strconv.FormatFloat(float64(ticks)/float64(scale), 'f', -1, 64)
+}
+
+// relativeTimes shifts the processes' arrivals and deadlines in place so the first arrival is at zero, returning
+// how far they moved. Durations, and so the order and length of everything scheduled, are unchanged.
+func relativeTimes(processes []Process) int64 {
+	if len(processes) == 0 {
+		return 0
+	}
+	first := processes[0].ArrivalTime
+	for i := range processes {
+		if processes[i].ArrivalTime < first {
+			first = processes[i].ArrivalTime
+		}
+	}
+	for i := range processes {
+		processes[i].ArrivalTime -= first
+		if processes[i].Deadline != 0 {
+			processes[i].Deadline -= first
+		}
+	}
+
+	return first
+}
+
+// ticksPerUnit is the options' time scale, at least one.
+func (o Options) ticksPerUnit() int64 {
+	if o.TimeScale < 1 {
+		return 1
+	}
+
+	return o.TimeScale
+}
+
+// quantum is the round-robin time quantum in ticks.
+func (o Options) quantum() int64 {
+	return defaultTimeQuantum * o.ticksPerUnit()
+}
+
+// withTimeScale returns the options with durations given in units of time converted to ticks at the scale.
+func (o Options) withTimeScale(scale int64) Options {
+	o.TimeScale = scale
+	o.MigrationCost *= scale
+	o.DispatchLatency *= scale
+	o.UtilityDecay *= scale
+	o.StarvationWait *= scale
+	o.MaxWait *= scale
+	o.Warmup *= scale
+	o.Window *= scale
+	o.GanttTicks *= scale
+	o.SnapshotAt *= scale
+	if o.InspectAt != nil {
+		inspectAt := make([]int64, len(o.InspectAt))
+		for i, at := range o.InspectAt {
+			inspectAt[i] = at * scale
+		}
+		o.InspectAt = inspectAt
+	}
+	o.MaxTime *= scale
+	o.Jitter *= scale
+	o.InitialPrediction *= scale
+	o.ThroughputWindow *= scale
+	o.BurstBucket *= scale
+	o.AgingInterval *= scale
+
+	return o
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_timeScale(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		fields  []string
+		want    int64
+		wantErr error
+	}{
+		{name: "integers", fields: []string{"1", "20", "0"}, want: 1},
+		{name: "most decimals wins", fields: []string{"1.5", "0.25", "3"}, want: 100},
+		{name: "too precise", fields: []string{"0.1234567"}, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := timeScale(tt.fields...)
+			if got != tt.want {
+				t.Errorf("timeScale() = %v, want %v", got, tt.want)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_parseTicks(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		s       string
+		scale   int64
+		want    int64
+		wantErr bool
+	}{
+		{name: "integer", s: "7", scale: 1, want: 7},
+		{name: "integer at scale", s: "7", scale: 100, want: 700},
+		{name: "padded fraction", s: "1.5", scale: 100, want: 150},
+		{name: "exact fraction", s: "0.25", scale: 100, want: 25},
+		{name: "more places than scale", s: "0.125", scale: 100, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseTicks(tt.s, tt.scale)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTicks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseTicks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_formatTime(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		ticks, scale int64
+		want         string
+	}{
+		{ticks: 12, scale: 1, want: "12"},
+		{ticks: 125, scale: 100, want: "1.25"},
+		{ticks: 300, scale: 100, want: "3"},
+	}
+	for _, tt := range tests {
+		if got := formatTime(tt.ticks, tt.scale); got != tt.want {
+			t.Errorf("formatTime(%v, %v) = %v, want %v", tt.ticks, tt.scale, got, tt.want)
+		}
+	}
+}
+
+func Test_loadProcesses_fractional(t *testing.T) {
+	t.Parallel()
+	got, scale, err := loadProcesses(strings.NewReader("1,2.5,0\n2,1.25,0.5"))
+	if err != nil {
+		t.Fatalf("loadProcesses() unexpected error: %v", err)
+	}
+	if scale != 100 {
+		t.Errorf("loadProcesses() scale = %v, want 100", scale)
+	}
+	want := []Process{
+		{ProcessID: 1, BurstDuration: 250, Line: 1},
+		{ProcessID: 2, ArrivalTime: 50, BurstDuration: 125, Line: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("loadProcesses() = %v, want %v", got, want)
+	}
+}
+
+func Test_fractionalSchedules(t *testing.T) {
+	t.Parallel()
+	// A workload at 100 ticks per unit schedules exactly as the integer workload with every time multiplied by 100.
+	integer := []Process{
+		{ProcessID: 1, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1, Priority: 3},
+	}
+	scaled := make([]Process, len(integer))
+	for i, p := range integer {
+		p.ArrivalTime *= 100
+		p.BurstDuration *= 100
+		scaled[i] = p
+	}
+	tests := []struct {
+		name     string
+		schedule Scheduler
+	}{
+		{name: "FCFS", schedule: FCFSSchedule},
+		{name: "SJF", schedule: SJFSchedule},
+		{name: "Priority", schedule: SJFPrioritySchedule},
+		{name: "RR", schedule: RRSchedule},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			want := tt.schedule(tt.name, integer, DefaultOptions()).Gantt
+			for i := range want {
+				want[i].Start *= 100
+				want[i].Stop *= 100
+			}
+			got := tt.schedule(tt.name, scaled, DefaultOptions().withTimeScale(100)).Gantt
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%v gantt = %v, want %v", tt.name, got, want)
+			}
+		})
+	}
+}
+
+func Test_outputResult_fractional(t *testing.T) {
+	t.Parallel()
+	opts := DefaultOptions().withTimeScale(100)
+	processes := []Process{{ProcessID: 1, BurstDuration: 250}}
+	var w bytes.Buffer
+	outputResult(&w, FCFSSchedule("FCFS", processes, opts), opts)
+	if !strings.Contains(w.String(), "2.5") {
+		t.Errorf("outputResult() should report times in units, got:\n%v", w.String())
+	}
+}
+
+func Test_relativeTimes(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 1000, BurstDuration: 5, Deadline: 1010},
+		{ProcessID: 2, ArrivalTime: 1002, BurstDuration: 3},
+		{ProcessID: 3, ArrivalTime: 1020, BurstDuration: 2, Deadline: 1030},
+	}
+	absolute := FCFSSchedule("FCFS", processes, DefaultOptions())
+
+	shifted := make([]Process, len(processes))
+	copy(shifted, processes)
+	if origin := relativeTimes(shifted); origin != 1000 {
+		t.Errorf("relativeTimes() moved the times by %v, want 1000", origin)
+	}
+	if shifted[0].ArrivalTime != 0 || shifted[0].Deadline != 10 || shifted[1].Deadline != 0 {
+		t.Errorf("relativeTimes() = %+v, want the first arrival at 0 and only the deadlines given moved", shifted)
+	}
+
+	relative := FCFSSchedule("FCFS", shifted, DefaultOptions())
+	for i, s := range relative.Gantt {
+		if want := absolute.Gantt[i]; s.PID != want.PID || s.Start != want.Start-1000 || s.Stop != want.Stop-1000 {
+			t.Errorf("relative gantt slice %v = %v, want %v moved down by 1000", i, s, want)
+		}
+	}
+	for i, s := range relative.Stats {
+		want := absolute.Stats[i]
+		if s.Completion != want.Completion-1000 || s.Wait != want.Wait || s.Turnaround != want.Turnaround {
+			t.Errorf("relative stats of process %v = %+v, want %+v completing 1000 earlier", s.ProcessID, s, want)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tuiFrameDelay is how long the TUI shows each frame before advancing to the next event.
+const tuiFrameDelay = 400 * time.Millisecond
+
+// tuiUnblock is the TUI's own event for a process returning from I/O to the ready queue, which the event log leaves out.
+const tuiUnblock = "unblock"
+
+// tuiModel replays a schedule's events, tracking what the dashboard shows at the current time.
+type tuiModel struct {
+	result  SchedulerResult
+	events  []Event
+	next    int
+	time    int64
+	running []int64
+	ready   []int64
+	done    int
+}
+
+func newTUIModel(result SchedulerResult) *tuiModel {
+	events := scheduleEvents(result)
+	for _, s := range result.IOGantt {
+		events = append(events, Event{Time: s.Stop, Event: tuiUnblock, PID: s.PID})
+	}
+	// Returning from I/O goes after the running process stops but ahead of arrivals, as in preemptiveSchedule.
+	rank := func(e Event) int {
+		if e.Event == tuiUnblock {
+			return 1
+		}
+		return eventOrder[e.Event] * 2
+	}
+	sort.SliceStable(events, func(a, b int) bool {
+		if events[a].Time != events[b].Time {
+			return events[a].Time < events[b].Time
+		}
+		return rank(events[a]) < rank(events[b])
+	})
+
+	return &tuiModel{result: result, events: events, running: make([]int64, 0), ready: make([]int64, 0)}
+}
+
+// step applies every event at the next event time, returning false once there are none left.
+func (m *tuiModel) step() bool {
+	if m.next >= len(m.events) {
+		return false
+	}
+	m.time = m.events[m.next].Time
+	for m.next < len(m.events) && m.events[m.next].Time == m.time {
+		m.apply()
+	}
+
+	return true
+}
+
+// apply applies just the next event.
+func (m *tuiModel) apply() {
+	e := m.events[m.next]
+	m.time = e.Time
+	m.next++
+	switch e.Event {
+	case EventArrive, tuiUnblock:
+		m.ready = append(m.ready, e.PID)
+	case EventDispatch:
+		m.ready = removePID(m.ready, e.PID)
+		m.running = append(m.running, e.PID)
+	case EventPreempt:
+		m.running = removePID(m.running, e.PID)
+		m.ready = append(m.ready, e.PID)
+	case EventBlock:
+		m.running = removePID(m.running, e.PID)
+	case EventComplete:
+		m.running = removePID(m.running, e.PID)
+		m.done++
+	}
+}
+
+// removePID drops the first pid from pids.
+func removePID(pids []int64, pid int64) []int64 {
+	for i := range pids {
+		if pids[i] == pid {
+			return append(pids[:i], pids[i+1:]...)
+		}
+	}
+
+	return pids
+}
+
+// frame renders the dashboard at the model's time.
+func (m *tuiModel) frame(opts Options) string {
+	var b strings.Builder
+	_, _ = fmt.Fprintln(&b, m.result.Title)
+	_, _ = fmt.Fprintf(&b, "Time:      %v\n", formatTime(m.time, opts.TimeScale))
+	running := "idle"
+	if len(m.running) > 0 {
+		running = fmt.Sprint(m.running)
+	}
+	_, _ = fmt.Fprintf(&b, "Running:   %v\n", running)
+	_, _ = fmt.Fprintf(&b, "Ready:     %v\n", m.ready)
+
+	makespan := ganttMakespan(m.result.Gantt)
+	_, symbols := ganttLegend(m.result.Gantt)
+	bars := []byte(strings.Repeat(" ", ganttWidth))
+	var busy int64
+	for _, s := range m.result.Gantt {
+		if s.Start >= m.time {
+			continue
+		}
+		stop := s.Stop
+		if stop > m.time {
+			stop = m.time
+		}
+		busy += stop - s.Start
+		for c := ganttColumn(s.Start, makespan, ganttWidth); c < ganttColumn(stop, makespan, ganttWidth); c++ {
+			bars[c] = symbols[s.PID]
+		}
+	}
+	_, _ = fmt.Fprintf(&b, "|%s|\n", bars)
+
+	utilization := 0.0
+	if m.time > 0 {
+		utilization = float64(busy) / float64(m.time) * 100
+	}
+	_, _ = fmt.Fprintf(&b, "Completed: %v/%v  CPU busy: %v%%\n",
+		m.done, len(m.result.Stats), formatFloat(utilization, opts.Precision))
+
+	return b.String()
+}
+
+// isTerminal reports whether f is a terminal rather than a file or pipe.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// defaultWidth is the width output is wrapped to when it isn't going to a terminal.
+const defaultWidth = 80
+
+// terminalWidth is how many columns wide f is if it's a terminal, otherwise defaultWidth. The width is only read from
+// $COLUMNS, which shells set but don't always export, so a terminal without it is taken to be defaultWidth wide.
+func terminalWidth(f *os.File) int {
+	if isTerminal(f) {
+		if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+			return columns
+		}
+	}
+
+	return defaultWidth
+}
+
+// outputTUI animates the schedule event by event, redrawing the dashboard and calling sleep with tuiFrameDelay
+// after each frame, then leaves the full output on screen.
+func outputTUI(w io.Writer, result SchedulerResult, opts Options, sleep func(time.Duration)) {
+	m := newTUIModel(result)
+	for m.step() {
+		_, _ = fmt.Fprint(w, "\x1b[H\x1b[2J", m.frame(opts))
+		sleep(tuiFrameDelay)
+	}
+	_, _ = fmt.Fprint(w, "\x1b[H\x1b[2J")
+	outputResult(w, result, opts)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_tuiModel(t *testing.T) {
+	t.Parallel()
+	result := SchedulerResult{
+		Title: "Test",
+		Gantt: []TimeSlice{
+			{PID: 1, Start: 0, Stop: 2},
+			{PID: 2, Start: 2, Stop: 5},
+			{PID: 1, Start: 5, Stop: 6},
+		},
+		IOGantt: []TimeSlice{{PID: 1, Start: 2, Stop: 3}},
+		Stats: []ProcessStats{
+			{Process: Process{ProcessID: 1, BurstDuration: 3}, Completion: 6},
+			{Process: Process{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3}, Completion: 5},
+		},
+	}
+	m := newTUIModel(result)
+	frames := make(map[int64]string)
+	for m.step() {
+		frames[m.time] = m.frame(DefaultOptions())
+	}
+
+	tests := []struct {
+		time int64
+		want []string
+	}{
+		{time: 1, want: []string{"Time:      1", "Running:   [1]", "Ready:     [2]", "|##########", "CPU busy: 100.00%"}},
+		{time: 2, want: []string{"Running:   [2]", "Ready:     []"}},
+		{time: 3, want: []string{"Running:   [2]", "Ready:     [1]", "Completed: 0/2"}},
+		{time: 6, want: []string{"Running:   idle", "Completed: 2/2", "|" + strings.Repeat("#", 20) + strings.Repeat("*", 30) + strings.Repeat("#", 10) + "|"}},
+	}
+	for _, tt := range tests {
+		frame, ok := frames[tt.time]
+		if !ok {
+			t.Errorf("tuiModel has no frame at %v", tt.time)
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(frame, want) {
+				t.Errorf("frame at %v missing %q, got:\n%v", tt.time, want, frame)
+			}
+		}
+	}
+}
+
+func Test_outputTUI_framesAsRendered(t *testing.T) {
+	t.Parallel()
+	result := calculateCompletionStats("Test", []Process{
+		{ProcessID: 1, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1},
+	}, []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 3}})
+	var (
+		b      strings.Builder
+		frames []string
+	)
+	// Each frame must already be written when the TUI waits before the next.
+	outputTUI(&b, result, DefaultOptions(), func(d time.Duration) {
+		if d != tuiFrameDelay {
+			t.Errorf("outputTUI() slept %v, want %v", d, tuiFrameDelay)
+		}
+		frames = append(frames, b.String())
+	})
+
+	times := []string{"Time:      0", "Time:      1", "Time:      2", "Time:      3"}
+	if len(frames) != len(times) {
+		t.Fatalf("outputTUI() drew %v frames, want %v", len(frames), len(times))
+	}
+	for i, want := range times {
+		if !strings.HasSuffix(frames[i], newTUIModelAt(result, i).frame(DefaultOptions())) || !strings.Contains(frames[i], want) {
+			t.Errorf("outputTUI() frame %v written so far:\n%v\nwant it to end with the frame at %q", i, frames[i], want)
+		}
+		if i > 0 && !strings.HasPrefix(frames[i], frames[i-1]) {
+			t.Errorf("outputTUI() frame %v rewrote what was already written", i)
+		}
+	}
+}
+
+// newTUIModelAt is the result's model stepped to its ith event time.
+func newTUIModelAt(result SchedulerResult, i int) *tuiModel {
+	m := newTUIModel(result)
+	for ; i >= 0; i-- {
+		m.step()
+	}
+
+	return m
+}
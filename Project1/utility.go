@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// utility is what completing the process at completion is worth: its whole Value by its deadline, or always if it
+// has no deadline, then falling linearly to nothing over decay after the deadline. A zero decay is a hard deadline,
+// worth nothing once missed.
+func utility(p Process, completion, decay int64) float64 {
+	late := completion - p.Deadline
+	switch {
+	case p.Deadline == 0 || late <= 0:
+		return float64(p.Value)
+	case late >= decay:
+		return 0
+	default:
+		return float64(p.Value) * float64(decay-late) / float64(decay)
+	}
+}
+
+// hasValues reports if any of the processes has a value.
+func hasValues(processes []Process) bool {
+	for i := range processes {
+		if processes[i].Value != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UtilitySchedule (time-utility) preemptively runs the ready process with the highest marginal utility per unit
+// of time: the utility of completing it if it ran from now without interruption, over the CPU time it has left.
+// It picks again every tick, as the utilities decay past the deadlines. Ties go by opts.TieBreak, and the
+// processes' I/O is left out of when they'd complete.
+func UtilitySchedule(title string, inputProcesses []Process, opts Options) SchedulerResult {
+	gantt, ioGantt, err := preemptiveSchedule(inputProcesses, 1, opts, func(ready []*runnable, time int64) int {
+		density := func(r *runnable) float64 {
+			return utility(r.Process, time+r.Remaining, opts.UtilityDecay) / float64(r.Remaining)
+		}
+		best := 0
+		for i := 1; i < len(ready); i++ {
+			a, b := density(ready[i]), density(ready[best])
+			if a > b || a == b && opts.before(ready[i].Process, ready[best].Process, false) {
+				best = i
+			}
+		}
+		return best
+	})
+
+	result := calculateCompletionStats(title, inputProcesses, gantt)
+	result.IOGantt = ioGantt
+	result.Err = err
+
+	return result
+}
+
+// accruedUtility sums the utility of every completed process, and what they'd be worth all completed in time.
+func accruedUtility(stats []ProcessStats, decay int64) (float64, float64) {
+	var accrued, possible float64
+	for _, s := range stats {
+		accrued += utility(s.Process, s.Completion, decay)
+		possible += float64(s.Value)
+	}
+
+	return accrued, possible
+}
+
+// outputUtility reports the utility the schedule accrued.
+func outputUtility(w io.Writer, stats []ProcessStats, opts Options) {
+	accrued, possible := accruedUtility(stats, opts.UtilityDecay)
+	_, _ = fmt.Fprintf(w, "Accrued utility: %v of %v\n\n",
+		formatFloat(accrued, opts.Precision), formatFloat(possible, opts.Precision))
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_utility(t *testing.T) {
+	t.Parallel()
+	p := Process{ProcessID: 1, BurstDuration: 2, Deadline: 10, Value: 8}
+	tests := []struct {
+		name       string
+		process    Process
+		completion int64
+		decay      int64
+		want       float64
+	}{
+		{name: "in time", process: p, completion: 10, decay: 4, want: 8},
+		{name: "decaying", process: p, completion: 11, decay: 4, want: 6},
+		{name: "decayed", process: p, completion: 14, decay: 4, want: 0},
+		{name: "hard deadline", process: p, completion: 11, want: 0},
+		{name: "no deadline", process: Process{Value: 3}, completion: 100, decay: 4, want: 3},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := utility(tt.process, tt.completion, tt.decay); got != tt.want {
+				t.Errorf("utility() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUtilitySchedule(t *testing.T) {
+	t.Parallel()
+	// EDF runs the cheap process 1 first, leaving the valuable process 2 three late.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Deadline: 4, Value: 1},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4, Deadline: 5, Value: 10},
+	}
+	opts := DefaultOptions()
+	edf, _ := accruedUtility(EDFSchedule("edf", processes, opts).Stats, opts.UtilityDecay)
+	got := UtilitySchedule("utility", processes, opts)
+	accrued, possible := accruedUtility(got.Stats, opts.UtilityDecay)
+	if got.Gantt[0].PID != 2 {
+		t.Errorf("UtilitySchedule() gantt = %v, want process 2 first", got.Gantt)
+	}
+	if accrued != 10.6 || possible != 11 || accrued <= edf {
+		t.Errorf("accruedUtility() = %v of %v, want 10.6 of 11, more than EDF's %v", accrued, possible, edf)
+	}
+
+	var w bytes.Buffer
+	outputResult(&w, got, opts)
+	if want := "Accrued utility: 10.60 of 11.00"; !strings.Contains(w.String(), want) {
+		t.Errorf("outputResult() missing %q: %v", want, w.String())
+	}
+}
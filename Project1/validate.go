@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// WorkloadSummary describes a workload without scheduling it.
+type WorkloadSummary struct {
+	Processes     int
+	FirstArrival  int64
+	LastArrival   int64
+	TotalBurst    int64
+	AlreadySorted bool
+}
+
+// summarizeWorkload counts the processes, their arrival span and total burst.
+func summarizeWorkload(processes []Process) WorkloadSummary {
+	s := WorkloadSummary{Processes: len(processes), AlreadySorted: true}
+	for i, p := range processes {
+		if i == 0 || p.ArrivalTime < s.FirstArrival {
+			s.FirstArrival = p.ArrivalTime
+		}
+		if i == 0 || p.ArrivalTime > s.LastArrival {
+			s.LastArrival = p.ArrivalTime
+		}
+		if i > 0 && p.ArrivalTime < processes[i-1].ArrivalTime {
+			s.AlreadySorted = false
+		}
+		s.TotalBurst += p.BurstDuration
+	}
+
+	return s
+}
+
+// unsortedWarning is given when the input isn't sorted by arrival time, as main sorts it before scheduling.
+const unsortedWarning = "Warning: processes aren't sorted by arrival time, they will be sorted before scheduling"
+
+// unsortedFileOrderWarning is given instead of unsortedWarning with -no-safety-sort.
+const unsortedFileOrderWarning = "Warning: processes aren't sorted by arrival time, they will be scheduled in file order"
+
+// checkArrivals rejects negative arrival times, and warns to w when the processes aren't already sorted by arrival.
+func checkArrivals(w io.Writer, processes []Process, opts Options) error {
+	for _, p := range processes {
+		if p.ArrivalTime < 0 {
+			return fmt.Errorf("%w: process %v has a negative arrival time", ErrInvalidArgs, p.ProcessID)
+		}
+	}
+	if !summarizeWorkload(processes).AlreadySorted {
+		_, _ = fmt.Fprintln(w, opts.unsortedWarning())
+	}
+
+	return nil
+}
+
+// validateProcesses lists everything wrong with a workload: duplicate IDs, negative times and priorities,
+// affinities that no core permits, and periodic instances expandInstances can't expand.
+func validateProcesses(processes []Process, opts Options) []string {
+	problems := make([]string, 0)
+	seen := make(map[int64]bool, len(processes))
+	for _, p := range processes {
+		if seen[p.ProcessID] {
+			problems = append(problems, fmt.Sprintf("process %v: duplicate ID", p.ProcessID))
+		}
+		seen[p.ProcessID] = true
+		for _, field := range []struct {
+			name  string
+			value int64
+		}{
+			{"burst", p.BurstDuration},
+			{"arrival", p.ArrivalTime},
+			{"priority", p.Priority},
+			{"deadline", p.Deadline},
+			{"period", p.Period},
+		} {
+			if field.value < 0 {
+				problems = append(problems, fmt.Sprintf("process %v: negative %v %v",
+					p.ProcessID, field.name, formatTime(field.value, opts.TimeScale)))
+			}
+		}
+	}
+	if err := checkAffinity(processes, opts.Cores); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if _, err := expandInstances(processes); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	return problems
+}
+
+// unsortedWarning is the warning for input that isn't sorted by arrival time, depending on whether it will be.
+func (o Options) unsortedWarning() string {
+	if o.NoSafetySort {
+		return unsortedFileOrderWarning
+	}
+
+	return unsortedWarning
+}
+
+// dryRun prints the workload's summary, its burst histogram and any problems found by validateProcesses, erroring if there were any.
+func dryRun(w io.Writer, processes []Process, opts Options) error {
+	s := summarizeWorkload(processes)
+	_, _ = fmt.Fprintf(w, "Processes:     %v\n", s.Processes)
+	_, _ = fmt.Fprintf(w, "Arrival span:  %v to %v\n",
+		formatTime(s.FirstArrival, opts.TimeScale), formatTime(s.LastArrival, opts.TimeScale))
+	_, _ = fmt.Fprintf(w, "Total burst:   %v\n", formatTime(s.TotalBurst, opts.TimeScale))
+	if len(processes) > 0 {
+		outputBurstHistogram(w, burstHistogram(processes, opts.BurstBucket), opts)
+	}
+	if !s.AlreadySorted {
+		_, _ = fmt.Fprintln(w, opts.unsortedWarning())
+	}
+
+	problems := validateProcesses(processes, opts)
+	for _, p := range problems {
+		_, _ = fmt.Fprintln(w, "Error:", p)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %v problems found in the input file", ErrInvalidArgs, len(problems))
+	}
+
+	return nil
+}
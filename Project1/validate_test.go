@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func Test_dryRun(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		csv       string
+		wantLines []string
+		wantErr   error
+	}{
+		{
+			name: "valid",
+			csv:  "1,5,0\n2,3,4\n3,2,6",
+			wantLines: []string{
+				"Processes:     3",
+				"Arrival span:  0 to 6",
+				"Total burst:   10",
+				"Burst histogram",
+				"[0, 5)   ## 2",
+				"[5, 10)  # 1",
+			},
+		},
+		{
+			name: "malformed",
+			csv:  "1,5,4,0\n1,3,0,0\n2,-2,6,-1",
+			wantLines: []string{
+				"Warning: processes aren't sorted by arrival time",
+				"Error: process 1: duplicate ID",
+				"Error: process 2: negative burst -2",
+				"Error: process 2: negative priority -1",
+			},
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, scale, err := loadProcesses(strings.NewReader(tt.csv))
+			if err != nil {
+				t.Fatalf("loadProcesses() unexpected error: %v", err)
+			}
+			var w bytes.Buffer
+			err = dryRun(&w, processes, DefaultOptions().withTimeScale(scale))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("dryRun() error = %v, want %v", err, tt.wantErr)
+			}
+			for _, line := range tt.wantLines {
+				if !strings.Contains(w.String(), line) {
+					t.Errorf("dryRun() output missing %q, got:\n%v", line, w.String())
+				}
+			}
+		})
+	}
+}
+
+func Test_checkArrivals(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		csv         string
+		noSort      bool
+		wantWarning bool
+		wantErr     error
+	}{
+		{name: "sorted", csv: "1,5,0\n2,3,0\n3,2,4"},
+		{name: "unsorted", csv: "1,5,4\n2,3,0", wantWarning: true},
+		{name: "unsorted in file order", csv: "1,5,4\n2,3,0", noSort: true, wantWarning: true},
+		{name: "negative arrival", csv: "1,5,0\n2,3,-1", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, _, err := loadProcesses(strings.NewReader(tt.csv))
+			if err != nil {
+				t.Fatalf("loadProcesses() unexpected error: %v", err)
+			}
+			opts := DefaultOptions()
+			opts.NoSafetySort = tt.noSort
+			var w bytes.Buffer
+			if err := checkArrivals(&w, processes, opts); !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkArrivals() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := strings.Contains(w.String(), opts.unsortedWarning()); got != tt.wantWarning {
+				t.Errorf("checkArrivals() warned = %v, want %v", got, tt.wantWarning)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// SteadyState are the averages over only the processes completing inside the measurement window.
+type SteadyState struct {
+	Start, End    int64
+	Processes     int
+	AveWait       float64
+	AveTurnaround float64
+	Throughput    float64
+}
+
+// windowed reports whether a warmup or window was given, asking for the steady-state metrics.
+func (o Options) windowed() bool {
+	return o.Warmup > 0 || o.Window > 0
+}
+
+// steadyState averages the wait and turnaround of the processes completing from opts.Warmup up to opts.Window,
+// or the last completion without a window. Throughput is over the window's length rather than the whole run.
+func steadyState(result SchedulerResult, opts Options) SteadyState {
+	s := SteadyState{Start: opts.Warmup, End: opts.Window}
+	if s.End == 0 {
+		for _, st := range result.Stats {
+			if st.Completion > s.End {
+				s.End = st.Completion
+			}
+		}
+	}
+
+	var totalWait, totalTurnaround float64
+	for _, st := range result.Stats {
+		if st.Completion < s.Start || st.Completion > s.End {
+			continue
+		}
+		s.Processes++
+		totalWait += float64(st.Wait)
+		totalTurnaround += float64(st.Turnaround)
+	}
+	if s.Processes == 0 {
+		return s
+	}
+
+	count := float64(s.Processes)
+	s.AveWait = totalWait / count
+	s.AveTurnaround = totalTurnaround / count
+	if s.End > s.Start {
+		s.Throughput = count / float64(s.End-s.Start)
+	}
+
+	return s
+}
+
+func outputSteadyState(w io.Writer, s SteadyState, opts Options) {
+	scale := float64(opts.ticksPerUnit())
+	_, _ = fmt.Fprintf(w, "Steady state [%v, %v]: %v processes, average wait %v, average turnaround %v, throughput %v/t\n\n",
+		formatTime(s.Start, opts.TimeScale),
+		formatTime(s.End, opts.TimeScale),
+		s.Processes,
+		formatFloat(s.AveWait/scale, opts.Precision),
+		formatFloat(s.AveTurnaround/scale, opts.Precision),
+		formatFloat(s.Throughput*scale, opts.Precision))
+}
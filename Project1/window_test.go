@@ -0,0 +1,56 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_steadyState(t *testing.T) {
+	t.Parallel()
+	// The first short jobs finish with almost no wait, a start-up transient that pulls the full-run averages down.
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 20},
+		{ProcessID: 2, BurstDuration: 2},
+		{ProcessID: 3, BurstDuration: 2},
+		{ProcessID: 4, BurstDuration: 2},
+		{ProcessID: 5, BurstDuration: 2},
+	}
+	result := SJFSchedule("SJF", processes, DefaultOptions())
+
+	tests := []struct {
+		name string
+		opts func(*Options)
+		want SteadyState
+	}{
+		{
+			name: "warmup only",
+			opts: func(o *Options) { o.Warmup = 3 },
+			want: SteadyState{Start: 3, End: 28, Processes: 4, AveWait: 5, AveTurnaround: 11.5, Throughput: 4.0 / 25},
+		},
+		{
+			name: "warmup and window",
+			opts: func(o *Options) { o.Warmup, o.Window = 5, 8 },
+			want: SteadyState{Start: 5, End: 8, Processes: 2, AveWait: 5, AveTurnaround: 7, Throughput: 2.0 / 3},
+		},
+		{
+			name: "empty window",
+			opts: func(o *Options) { o.Warmup, o.Window = 9, 20 },
+			want: SteadyState{Start: 9, End: 20},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opts := DefaultOptions()
+			tt.opts(&opts)
+			got := steadyState(result, opts)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("steadyState() = %+v, want %+v", got, tt.want)
+			}
+			if got.Processes > 0 && got.AveWait == result.AveWait {
+				t.Errorf("steadyState() average wait should differ from the full run's %v", result.AveWait)
+			}
+		})
+	}
+}